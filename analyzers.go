@@ -27,12 +27,17 @@ type analyzer struct {
 	Custom      AnalyzerCustom
 }
 
-// NewAnalyzer() applies an AnalyzerFunc to return a map structure used in Elasticsearch settings.
-func (a analysis) NewAnalyzer(analyzer AnalyzerFunc) map[string]interface{} {
+// NewAnalyzer() applies one or more AnalyzerFuncs to return a map structure used in Elasticsearch settings.
+func (a analysis) NewAnalyzer(analyzers ...AnalyzerFunc) map[string]interface{} {
 	r := map[string]interface{}{}
 
-	for k, v := range analyzer() {
-		r[k] = v
+	for _, analyzer := range analyzers {
+		if analyzer == nil {
+			continue
+		}
+		for k, v := range analyzer() {
+			r[k] = v
+		}
 	}
 
 	return r
@@ -549,7 +554,7 @@ var (
 	AnalyzerCustomTokenizerPathHierarchy      AnalyzerCustomTokenizer = "path_hierarchy"
 )
 
-// WithTokenizer() sets the tokenizer for a custom analyzer.
+// WithTokenizer() sets the tokenizer for a custom analyzer. A tokenizer defined in AnalysisConfig.Tokenizer is referenced by its name, e.g. AnalyzerCustomTokenizer("autocomplete").
 func (c AnalyzerCustom) WithTokenizer(value AnalyzerCustomTokenizer) AnalyzerCustomProperties {
 	return func() map[string]interface{} {
 		return map[string]interface{}{
@@ -567,7 +572,7 @@ var (
 	AnalyzerCustomCharFilterPatternReplace AnalyzerCustomCharFilter = "pattern_replace"
 )
 
-// WithCharFilter() adds one or more character filters to a custom analyzer.
+// WithCharFilter() adds one or more character filters to a custom analyzer. A character filter defined in AnalysisConfig.CharFilter is referenced by its name, e.g. AnalyzerCustomCharFilter("strip_tags").
 func (c AnalyzerCustom) WithCharFilter(value []AnalyzerCustomCharFilter) AnalyzerCustomProperties {
 	return func() map[string]interface{} {
 		return map[string]interface{}{
@@ -630,7 +635,7 @@ var (
 	AnalyzerCustomFilterWordDelimiterGraph    AnalyzerCustomFilter = "word_delimiter_graph"
 )
 
-// WithFilter() sets the list of token filters to apply to the output of the tokenizer.
+// WithFilter() sets the list of token filters to apply to the output of the tokenizer. A filter defined in AnalysisConfig.Filter is referenced by its name, e.g. AnalyzerCustomFilter("product_synonyms").
 func (c AnalyzerCustom) WithFilter(value []AnalyzerCustomFilter) AnalyzerCustomProperties {
 	return func() map[string]interface{} {
 		return map[string]interface{}{
@@ -0,0 +1,181 @@
+package porter
+
+import (
+	"strings"
+)
+
+/*
+
+This file contains the factory functions and types for constructing configurable character filters. The
+built-in "mapping" and "pattern_replace" character filters are unusable without their mappings or pattern,
+so the character filters defined here carry them (e.g., WithMappings(), WithReplacement()) and are placed
+into AnalysisConfig.CharFilter.
+
+Custom analyzers and normalizers reference a configured character filter by its name, the same way they
+reference a built-in one:
+
+	Normalizer.Custom.WithCharFilter([]porter.NormalizerCustomCharFilter{"sku_separators"})
+
+*/
+
+type charFilter struct {
+	HTMLStrip      CharFilterHTMLStrip
+	Mapping        CharFilterMapping
+	PatternReplace CharFilterPatternReplace
+}
+
+// NewCharFilter() generates a map of character filter names and their corresponding definitions.
+func (a analysis) NewCharFilter(charFilters ...CharFilterFunc) map[string]interface{} {
+	r := map[string]interface{}{}
+
+	for _, fn := range charFilters {
+		if fn == nil {
+			continue
+		}
+		for k, v := range fn() {
+			r[k] = v
+		}
+	}
+
+	return r
+}
+
+type CharFilterFunc func() map[string]interface{}
+
+// HTML STRIP CHAR FILTER
+
+type CharFilterHTMLStripProperties func() map[string]interface{}
+type CharFilterHTMLStrip func(name string, properties ...CharFilterHTMLStripProperties) CharFilterFunc
+
+func newCharFilterHTMLStrip() CharFilterHTMLStrip {
+	return func(name string, properties ...CharFilterHTMLStripProperties) CharFilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "html_strip"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithEscapedTags() sets the HTML tags (without angle brackets) that are kept in the text.
+func (h CharFilterHTMLStrip) WithEscapedTags(value []string) CharFilterHTMLStripProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"escaped_tags": value,
+		}
+	}
+}
+
+// MAPPING CHAR FILTER
+
+type CharFilterMappingProperties func() map[string]interface{}
+type CharFilterMapping func(name string, properties ...CharFilterMappingProperties) CharFilterFunc
+
+func newCharFilterMapping() CharFilterMapping {
+	return func(name string, properties ...CharFilterMappingProperties) CharFilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "mapping"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithMappings() sets the inline mappings in the "key => value" format (e.g., "ß => ss").
+func (m CharFilterMapping) WithMappings(value []string) CharFilterMappingProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"mappings": value,
+		}
+	}
+}
+
+// WithMappingsPath() sets an external mappings file path.
+func (m CharFilterMapping) WithMappingsPath(value string) CharFilterMappingProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"mappings_path": value,
+		}
+	}
+}
+
+// PATTERN REPLACE CHAR FILTER
+
+type CharFilterPatternReplaceProperties func() map[string]interface{}
+type CharFilterPatternReplace func(name string, pattern string, properties ...CharFilterPatternReplaceProperties) CharFilterFunc
+
+func newCharFilterPatternReplace() CharFilterPatternReplace {
+	return func(name string, pattern string, properties ...CharFilterPatternReplaceProperties) CharFilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "pattern_replace"
+			r["pattern"] = pattern
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithReplacement() sets the replacement string; capture groups are referenced as $1.
+func (p CharFilterPatternReplace) WithReplacement(value string) CharFilterPatternReplaceProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"replacement": value,
+		}
+	}
+}
+
+// WithFlags() configures regex flags (e.g., CASE_INSENSITIVE).
+func (p CharFilterPatternReplace) WithFlags(value []AnalyzerPatternFlags) CharFilterPatternReplaceProperties {
+	return func() map[string]interface{} {
+		flags := []string{}
+
+		for _, v := range value {
+			flags = append(flags, string(v))
+		}
+
+		f := strings.Join(flags, "|")
+
+		return map[string]interface{}{
+			"flags": f,
+		}
+	}
+}
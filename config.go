@@ -11,11 +11,20 @@ the settings and mappings configurations.
 The SettingsConfig{} struct allows users to define index-specific settings, such as the number of
 shards and replicas, as well as custom analysis configurations (analyzers and normalizers).
 
+Time series (TSDB) indices are configured through the Mode, RoutingPath and TimeSeries settings, and
+custom scoring through the Similarity settings.
+
 The AnalysisConfig{} struct defines the custom analyzers and normalizers used for text analysis in the
-index.
+index, as well as the configured tokenizers, token filters and character filters they reference.
 
 The MappingsConfig{} struct contains the properties of the index, mapping each field name to its
-definition and properties.
+definition and properties, as well as the runtime fields evaluated at query time.
+
+The SynonymsSetConfig{} struct describes a synonym set managed through the Synonyms API, which can be
+updated without reindexing.
+
+The ScriptConfig{} struct describes a painless script, and the SearchConfig{} struct describes a
+verification query that can carry its own search-time runtime mappings.
 
 This structure is designed to provide an easy way to define and manage Elasticsearch index configurations
 in a structured and flexible manner, facilitating the creation of indices with appropriate settings and mappings.
@@ -36,18 +45,81 @@ type DefinitionConfig struct {
 
 // SettingsConfig{} defines the settings related to an Elasticsearch index, including the number of shards, replicas, and custom analysis configurations.
 type SettingsConfig struct {
-	NumberOfShards   int             `json:"number_of_shards,omitempty"`
-	NumberOfReplicas int             `json:"number_of_replicas,omitempty"`
-	Analysis         *AnalysisConfig `json:"analysis,omitempty"`
+	NumberOfShards   int                    `json:"number_of_shards,omitempty"`
+	NumberOfReplicas int                    `json:"number_of_replicas,omitempty"`
+	Analysis         *AnalysisConfig        `json:"analysis,omitempty"`
+	Mapping          *MappingSettingsConfig `json:"mapping,omitempty"`
+	Mode             IndexMode              `json:"mode,omitempty"`
+	RoutingPath      []string               `json:"routing_path,omitempty"`
+	TimeSeries       *TimeSeriesConfig      `json:"time_series,omitempty"`
+	Similarity       map[string]interface{} `json:"similarity,omitempty"`
+	MaxNGramDiff     int                    `json:"max_ngram_diff,omitempty"`
+}
+
+// IndexMode defines the mode of an Elasticsearch index.
+type IndexMode string
+
+var (
+	IndexModeStandard   IndexMode = "standard"
+	IndexModeTimeSeries IndexMode = "time_series"
+)
+
+// TimeSeriesConfig{} defines the time range accepted by a time series (TSDB) index. Times are formatted as strict_date_optional_time.
+type TimeSeriesConfig struct {
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+}
+
+// MappingSettingsConfig{} holds the mapping limits of an Elasticsearch index, such as the number of nested fields and nested objects allowed.
+type MappingSettingsConfig struct {
+	NestedFields  *LimitConfig `json:"nested_fields,omitempty"`
+	NestedObjects *LimitConfig `json:"nested_objects,omitempty"`
+	Depth         *LimitConfig `json:"depth,omitempty"`
+}
+
+// LimitConfig{} defines a single mapping limit.
+type LimitConfig struct {
+	Limit int `json:"limit"`
 }
 
 // AnalysisConfig{} holds custom analysis settings for the Elasticsearch index, including analyzers and normalizers to control text processing during indexing and searching.
 type AnalysisConfig struct {
 	Analyzer   map[string]interface{} `json:"analyzer,omitempty"`
 	Normalizer map[string]interface{} `json:"normalizer,omitempty"`
+	Tokenizer  map[string]interface{} `json:"tokenizer,omitempty"`
+	Filter     map[string]interface{} `json:"filter,omitempty"`
+	CharFilter map[string]interface{} `json:"char_filter,omitempty"`
 }
 
 // MappingsConfig{} defines the field mappings for an Elasticsearch index, including the types and properties for each field in the index.
 type MappingsConfig struct {
 	Properties map[string]interface{} `json:"properties,omitempty"`
+	Runtime    map[string]interface{} `json:"runtime,omitempty"`
+}
+
+// SynonymsSetConfig{} defines a synonym set stored through the Synonyms API and referenced by synonym filters via WithSynonymsSet().
+type SynonymsSetConfig struct {
+	ID    string               `json:"-"`
+	Rules []SynonymsRuleConfig `json:"synonyms_set"`
+}
+
+// SynonymsRuleConfig{} defines a single rule of a synonym set in the Solr format.
+type SynonymsRuleConfig struct {
+	ID       string `json:"id,omitempty"`
+	Synonyms string `json:"synonyms"`
+}
+
+// ScriptConfig{} defines a script, its language and the parameters passed to it.
+type ScriptConfig struct {
+	Source string                 `json:"source"`
+	Lang   string                 `json:"lang,omitempty"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// SearchConfig{} defines a search request used to verify an index, including search-time runtime mappings that exist only for the duration of the request.
+type SearchConfig struct {
+	RuntimeMappings map[string]interface{} `json:"runtime_mappings,omitempty"`
+	Query           map[string]interface{} `json:"query,omitempty"`
+	Fields          []string               `json:"fields,omitempty"`
+	Size            int                    `json:"size,omitempty"`
 }
@@ -1,8 +1,17 @@
 package porter
 
 import (
+	"encoding/base64"
 	"fmt"
+	"math"
+	"math/rand"
+	"net"
 	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
@@ -17,7 +26,8 @@ documents using field-specific fake data generators.
 It supports two sources of data: reading raw documents from files or dynamically generating
 structured documents with realistic values using "gofakeit". Each field type maps to a
 specific generator, ensuring values match Elasticsearch
-index expectations.
+index expectations. Object and nested fields are generated recursively, nested fields
+producing arrays of objects with a configurable cardinality.
 
 The goal is to enable fast and accurate test data generation for Elasticsearch-based systems,
 with type-safe mappings and reusable, composable generation logic.
@@ -25,12 +35,18 @@ with type-safe mappings and reusable, composable generation logic.
 */
 
 var (
-	ErrOriginFromFile = fmt.Errorf("origin: failed to read documents from file")
+	ErrOriginFromFile    = fmt.Errorf("origin: failed to read documents from file")
+	ErrOriginNoJoinField = fmt.Errorf("origin: no join field with a parent/child relation is defined")
+	ErrOriginTimeWindow  = fmt.Errorf("origin: time series window must have a positive interval and end after it starts")
+	ErrOriginTimeSeries  = fmt.Errorf("origin: dimension fakes cannot produce enough unique series")
 )
 
 type location struct {
-	FromFile LocationFromFile
-	Generate LocationGenerate
+	FromFile     LocationFromFile
+	Generate     LocationGenerate
+	GenerateJoin LocationGenerateJoin
+
+	GenerateTimeSeries LocationGenerateTimeSeries
 }
 
 type OriginFunc func(t Temp) ([]byte, error)
@@ -56,6 +72,8 @@ func newLocationGenerate() LocationGenerate {
 		return func(t Temp) ([]byte, error) {
 			var docs []byte
 
+			generators := snapshotToGenerate()
+
 			for c := 1; c <= amount; c++ {
 				m := map[string]interface{}{
 					"index": map[string]interface{}{
@@ -64,13 +82,7 @@ func newLocationGenerate() LocationGenerate {
 					},
 				}
 
-				f := map[string]interface{}{}
-
-				for k, v := range toGenerate {
-					data := generateFakeData(v)
-
-					f[k] = data
-				}
+				f := generateFakeObject(generators)
 
 				mB := utils.MarshalJSON(m)
 				fB := utils.MarshalJSON(f)
@@ -86,6 +98,202 @@ func newLocationGenerate() LocationGenerate {
 	}
 }
 
+type LocationGenerateJoin func(parents int, children int) OriginFunc
+
+// newLocationGenerateJoin() generates documents for every relation of the join field. Each top-level parent
+// relation gets the given number of parent documents, and every document of a relation with children gets
+// the given number of child documents for each of its child relations, down to the last level. Children
+// reference their parent through the join field and are routed to the shard of the top-level parent.
+func newLocationGenerateJoin() LocationGenerateJoin {
+	return func(parents int, children int) OriginFunc {
+		return func(t Temp) ([]byte, error) {
+			var docs []byte
+
+			generators := snapshotToGenerate()
+
+			var (
+				field string
+				join  *generatorJoin
+			)
+
+			names := []string{}
+			for k, v := range generators {
+				if v.join != nil {
+					names = append(names, k)
+				}
+			}
+
+			sort.Strings(names)
+
+			// An index has a single join field, so the one mapped by the index wins over join fields of other definitions.
+			for _, k := range names {
+				if join == nil || isMappedField(t.Config, k) {
+					field, join = k, generators[k].join
+				}
+			}
+			if join == nil {
+				return nil, ErrOriginNoJoinField
+			}
+
+			id := 0
+
+			var generate func(relation string, parentID string, routing string)
+			generate = func(relation string, parentID string, routing string) {
+				id++
+
+				docID := strconv.Itoa(id)
+
+				index := map[string]interface{}{
+					"_index": t.Config.Name,
+					"_id":    docID,
+				}
+
+				f := generateFakeObject(generators)
+
+				if parentID == "" {
+					f[field] = relation
+					routing = docID
+				} else {
+					index["routing"] = routing
+					f[field] = map[string]interface{}{
+						"name":   relation,
+						"parent": parentID,
+					}
+				}
+
+				docs = append(docs, utils.MarshalJSON(map[string]interface{}{"index": index})...)
+				docs = append(docs, '\n')
+				docs = append(docs, utils.MarshalJSON(f)...)
+				docs = append(docs, '\n')
+
+				for _, child := range join.relations[relation] {
+					for c := 1; c <= children; c++ {
+						generate(child, docID, routing)
+					}
+				}
+			}
+
+			for _, root := range join.roots {
+				for p := 1; p <= parents; p++ {
+					generate(root, "", "")
+				}
+			}
+
+			return docs, nil
+		}
+	}
+}
+
+// isMappedField() reports whether a top-level field is part of the mappings of the index definition.
+func isMappedField(config Config, name string) bool {
+	if config.Definition.Mappings == nil {
+		return false
+	}
+
+	_, ok := config.Definition.Mappings.Properties[name]
+
+	return ok
+}
+
+// FakeTimeWindow defines the time range and the interval between two points of a generated time series.
+type FakeTimeWindow struct {
+	Start    time.Time
+	End      time.Time
+	Interval time.Duration
+}
+
+type LocationGenerateTimeSeries func(series int, window FakeTimeWindow) OriginFunc
+
+// newLocationGenerateTimeSeries() generates documents for a time series data stream: every series has a
+// stable, unique combination of dimension values, and a point for every interval of the window. Counter
+// metrics increase monotonically within a series, gauges are generated freshly for every point. When the
+// dimension fakes run out of unique combinations, it fails instead of emitting series that would conflict.
+func newLocationGenerateTimeSeries() LocationGenerateTimeSeries {
+	return func(series int, window FakeTimeWindow) OriginFunc {
+		return func(t Temp) ([]byte, error) {
+			if window.Interval <= 0 || !window.End.After(window.Start) {
+				return nil, ErrOriginTimeWindow
+			}
+
+			var docs []byte
+
+			generators := snapshotToGenerate()
+
+			seen := map[string]bool{}
+
+			for s := 1; s <= series; s++ {
+				dimensions := map[string]interface{}{}
+				unique := false
+
+				// Dimension combinations identify a series (its _tsid), so they are regenerated until they are unique.
+				for attempt := 0; attempt < 100; attempt++ {
+					dimensions = map[string]interface{}{}
+
+					for k, v := range generators {
+						if !v.dimension {
+							continue
+						}
+
+						data, ok := generateFakeData(v)
+						if !ok {
+							continue
+						}
+
+						dimensions[k] = data
+					}
+
+					if !seen[string(utils.MarshalJSON(dimensions))] {
+						unique = true
+
+						break
+					}
+				}
+
+				if !unique {
+					return nil, fmt.Errorf("%w [%d of %d series]", ErrOriginTimeSeries, s-1, series)
+				}
+
+				seen[string(utils.MarshalJSON(dimensions))] = true
+
+				counters := map[string]int{}
+				for k, v := range generators {
+					if v.metric == FieldTimeSeriesMetricCounter {
+						counters[k] = gofakeit.IntRange(0, 1000)
+					}
+				}
+
+				for ts := window.Start; ts.Before(window.End); ts = ts.Add(window.Interval) {
+					m := map[string]interface{}{
+						"create": map[string]interface{}{
+							"_index": t.Config.Name,
+						},
+					}
+
+					f := generateFakeObject(generators)
+
+					for k, v := range dimensions {
+						f[k] = v
+					}
+					for k := range counters {
+						counters[k] += gofakeit.IntRange(0, 100)
+
+						f[k] = counters[k]
+					}
+
+					f["@timestamp"] = ts.UTC().Format("2006-01-02T15:04:05.000Z07:00")
+
+					docs = append(docs, utils.MarshalJSON(m)...)
+					docs = append(docs, '\n')
+					docs = append(docs, utils.MarshalJSON(f)...)
+					docs = append(docs, '\n')
+				}
+			}
+
+			return docs, nil
+		}
+	}
+}
+
 type Fake string
 
 var (
@@ -182,178 +390,1181 @@ var (
 	FakeIPIPv6 FakeIP = "ipv6"
 )
 
-var toGenerate = map[string]string{}
+type FakeWildcard string
+
+var (
+	FakeWildcardLogLine FakeWildcard = "log_line"
+	FakeWildcardURL     FakeWildcard = "url"
+)
+
+type FakeFlattened string
+
+var (
+	FakeFlattenedObject FakeFlattened = "flattened_object"
+)
+
+type FakeUnsignedLong string
+
+var (
+	FakeUnsignedLongInt FakeUnsignedLong = "unsigned_long"
+)
+
+type FakeDateNanos string
+
+var (
+	FakeDateNanosTimestamp FakeDateNanos = "timestamp_nanos"
+)
+
+type FakeBinary string
+
+var (
+	FakeBinaryBase64 FakeBinary = "base64"
+)
+
+type FakeVersion string
+
+var (
+	FakeVersionSemver FakeVersion = "semver"
+)
+
+type FakeHistogram string
+
+var (
+	FakeHistogramValues FakeHistogram = "histogram"
+)
+
+type FakeAggregateMetricDouble string
+
+var (
+	FakeAggregateMetricDoubleMetrics FakeAggregateMetricDouble = "aggregate_metric"
+)
+
+type FakeRankFeature string
+
+var (
+	FakeRankFeatureFloat FakeRankFeature = "rank_feature"
+)
+
+// FakePercolator generates the queries stored in percolator fields.
+type FakePercolator func() interface{}
+
+// FakePercolatorMatch() generates match queries against the given field, with query text produced by the given fake.
+func FakePercolatorMatch(field string, fake Fake) FakePercolator {
+	return func() interface{} {
+		fc, ok := fakeFuncs[string(fake)]
+		if !ok {
+			return map[string]interface{}{"match_all": map[string]interface{}{}}
+		}
+
+		return map[string]interface{}{
+			"match": map[string]interface{}{
+				field: fc(),
+			},
+		}
+	}
+}
+
+// FakePercolatorTerm() generates term queries against the given field, with values produced by the given fake.
+func FakePercolatorTerm(field string, fake Fake) FakePercolator {
+	return func() interface{} {
+		fc, ok := fakeFuncs[string(fake)]
+		if !ok {
+			return map[string]interface{}{"match_all": map[string]interface{}{}}
+		}
+
+		return map[string]interface{}{
+			"term": map[string]interface{}{
+				field: fc(),
+			},
+		}
+	}
+}
+
+type FakeIntegerRange string
+
+var (
+	FakeIntegerRangeInt FakeIntegerRange = "int_range"
+)
+
+type FakeLongRange string
+
+var (
+	FakeLongRangeInt FakeLongRange = "int_range"
+)
+
+type FakeFloatRange string
+
+var (
+	FakeFloatRangeFloat FakeFloatRange = "float_range"
+)
+
+type FakeDoubleRange string
+
+var (
+	FakeDoubleRangeFloat FakeDoubleRange = "float_range"
+)
+
+type FakeDateRange string
+
+var (
+	FakeDateRangeDate FakeDateRange = "date_range"
+)
+
+type FakeIPRange string
+
+var (
+	FakeIPRangeIPv4 FakeIPRange = "ipv4_range"
+	FakeIPRangeIPv6 FakeIPRange = "ipv6_range"
+)
+
+// FakeBoundingBox defines the area geo values are generated in. For point and shape fields the bounds are treated as cartesian x/y values.
+type FakeBoundingBox struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+var (
+	FakeBoundingBoxWorld = FakeBoundingBox{MinLon: -180, MinLat: -90, MaxLon: 180, MaxLat: 90}
+)
+
+// FakeGeoCity defines the center of a well-known city that geo values can be generated around.
+type FakeGeoCity struct {
+	Lat float64
+	Lon float64
+}
+
+var (
+	FakeGeoCityLondon       = FakeGeoCity{Lat: 51.5074, Lon: -0.1278}
+	FakeGeoCityParis        = FakeGeoCity{Lat: 48.8566, Lon: 2.3522}
+	FakeGeoCityBerlin       = FakeGeoCity{Lat: 52.5200, Lon: 13.4050}
+	FakeGeoCityMoscow       = FakeGeoCity{Lat: 55.7558, Lon: 37.6173}
+	FakeGeoCityDubai        = FakeGeoCity{Lat: 25.2048, Lon: 55.2708}
+	FakeGeoCityTokyo        = FakeGeoCity{Lat: 35.6762, Lon: 139.6503}
+	FakeGeoCitySydney       = FakeGeoCity{Lat: -33.8688, Lon: 151.2093}
+	FakeGeoCityNewYork      = FakeGeoCity{Lat: 40.7128, Lon: -74.0060}
+	FakeGeoCitySanFrancisco = FakeGeoCity{Lat: 37.7749, Lon: -122.4194}
+	FakeGeoCitySaoPaulo     = FakeGeoCity{Lat: -23.5505, Lon: -46.6333}
+)
+
+// FakeBoundingBoxAround() returns the bounding box that covers the given radius (in kilometers) around a city.
+func FakeBoundingBoxAround(city FakeGeoCity, radius float64) FakeBoundingBox {
+	dLat, dLon := kilometersToDegrees(city.Lat, radius, radius)
+
+	return FakeBoundingBox{
+		MinLon: math.Max(city.Lon-dLon, -180),
+		MinLat: math.Max(city.Lat-dLat, -90),
+		MaxLon: math.Min(city.Lon+dLon, 180),
+		MaxLat: math.Min(city.Lat+dLat, 90),
+	}
+}
+
+// FakeGeoPoint generates values for geo_point and point fields as [lon, lat] (or [x, y]) arrays.
+type FakeGeoPoint func() interface{}
+
+// FakeGeoPointWithin() generates points uniformly distributed within a bounding box.
+func FakeGeoPointWithin(box FakeBoundingBox) FakeGeoPoint {
+	return func() interface{} {
+		return []float64{
+			roundCoordinate(gofakeit.Float64Range(box.MinLon, box.MaxLon)),
+			roundCoordinate(gofakeit.Float64Range(box.MinLat, box.MaxLat)),
+		}
+	}
+}
+
+// FakeGeoPointAround() generates points uniformly distributed within the given radius (in kilometers) around a city.
+func FakeGeoPointAround(city FakeGeoCity, radius float64) FakeGeoPoint {
+	return func() interface{} {
+		r := radius * math.Sqrt(gofakeit.Float64Range(0, 1))
+		theta := gofakeit.Float64Range(0, 2*math.Pi)
+
+		dLat, dLon := kilometersToDegrees(city.Lat, r*math.Sin(theta), r*math.Cos(theta))
+
+		return []float64{
+			roundCoordinate(math.Max(math.Min(city.Lon+dLon, 180), -180)),
+			roundCoordinate(math.Max(math.Min(city.Lat+dLat, 90), -90)),
+		}
+	}
+}
+
+// FakeGeoShape generates values for geo_shape and shape fields as GeoJSON geometries.
+type FakeGeoShape func() interface{}
+
+// FakeGeoShapePolygon() generates closed, counter-clockwise, non-self-intersecting polygons with the given number of vertices within a bounding box.
+func FakeGeoShapePolygon(box FakeBoundingBox, vertices int) FakeGeoShape {
+	if vertices < 3 {
+		vertices = 3
+	}
+
+	return func() interface{} {
+		maxRadius := math.Min(box.MaxLon-box.MinLon, box.MaxLat-box.MinLat) / 4
+		radius := gofakeit.Float64Range(maxRadius/2, maxRadius)
+
+		cx := gofakeit.Float64Range(box.MinLon+radius, box.MaxLon-radius)
+		cy := gofakeit.Float64Range(box.MinLat+radius, box.MaxLat-radius)
+
+		// Every vertex lies in its own angular sector around the center, so the ring is star-shaped
+		// and can never cross itself. Ascending angles keep it counter-clockwise.
+		sector := 2 * math.Pi / float64(vertices)
+
+		ring := [][]float64{}
+
+		for c := 0; c < vertices; c++ {
+			angle := sector*float64(c) + gofakeit.Float64Range(0.1*sector, 0.9*sector)
+			r := gofakeit.Float64Range(radius/2, radius)
+
+			ring = append(ring, []float64{
+				roundCoordinate(cx + r*math.Cos(angle)),
+				roundCoordinate(cy + r*math.Sin(angle)),
+			})
+		}
+
+		ring = append(ring, ring[0])
+
+		return map[string]interface{}{
+			"type":        "Polygon",
+			"coordinates": [][][]float64{ring},
+		}
+	}
+}
+
+// FakeGeoShapeLineString() generates non-self-intersecting linestrings with the given number of points within a bounding box.
+func FakeGeoShapeLineString(box FakeBoundingBox, points int) FakeGeoShape {
+	if points < 2 {
+		points = 2
+	}
+
+	return func() interface{} {
+		// Longitudes strictly increase along the line, so no two segments can cross.
+		step := (box.MaxLon - box.MinLon) / float64(points)
+
+		line := [][]float64{}
+
+		for c := 0; c < points; c++ {
+			line = append(line, []float64{
+				roundCoordinate(box.MinLon + step*float64(c) + gofakeit.Float64Range(0.1*step, 0.9*step)),
+				roundCoordinate(gofakeit.Float64Range(box.MinLat, box.MaxLat)),
+			})
+		}
+
+		return map[string]interface{}{
+			"type":        "LineString",
+			"coordinates": line,
+		}
+	}
+}
+
+func kilometersToDegrees(lat float64, north float64, east float64) (float64, float64) {
+	const kilometersPerDegree = 111.32
+
+	dLat := north / kilometersPerDegree
+	dLon := east / (kilometersPerDegree * math.Max(math.Cos(lat*math.Pi/180), 0.01))
+
+	return dLat, dLon
+}
+
+func roundCoordinate(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
+
+// FakeSparseVector defines how the token/weight maps of sparse_vector and rank_features fields are generated.
+type FakeSparseVector string
+
+var (
+	FakeSparseVectorWords FakeSparseVector = "sparse_words"
+)
+
+// FakeDenseVector generates vectors for dense_vector fields. It receives the dimensions and element type of the field it is used by.
+type FakeDenseVector func(dims int, elementType FieldDenseVectorElementType) func() interface{}
+
+// FakeDenseVectorNormalized() generates random unit-length vectors, suitable for the cosine and dot_product similarities.
+func FakeDenseVectorNormalized() FakeDenseVector {
+	return func(dims int, elementType FieldDenseVectorElementType) func() interface{} {
+		return func() interface{} {
+			return encodeVector(randomUnitVector(dims), elementType)
+		}
+	}
+}
+
+// FakeDenseVectorClustered() generates unit-length vectors scattered around k fixed centroids with the given spread, so that kNN searches have meaningful neighbors.
+func FakeDenseVectorClustered(k int, spread float64) FakeDenseVector {
+	if k < 1 {
+		k = 1
+	}
+
+	return func(dims int, elementType FieldDenseVectorElementType) func() interface{} {
+		centroids := [][]float64{}
+
+		for c := 0; c < k; c++ {
+			centroids = append(centroids, randomUnitVector(dims))
+		}
+
+		return func() interface{} {
+			centroid := centroids[gofakeit.IntRange(0, k-1)]
+
+			v := make([]float64, dims)
+			for i := range v {
+				v[i] = centroid[i] + rand.NormFloat64()*spread
+			}
+
+			return encodeVector(normalizeVector(v), elementType)
+		}
+	}
+}
+
+func randomUnitVector(dims int) []float64 {
+	v := make([]float64, dims)
+	for i := range v {
+		v[i] = rand.NormFloat64()
+	}
+
+	return normalizeVector(v)
+}
+
+func normalizeVector(v []float64) []float64 {
+	norm := 0.0
+	for _, x := range v {
+		norm += x * x
+	}
+
+	norm = math.Sqrt(norm)
+	if norm == 0 {
+		return v
+	}
+
+	for i := range v {
+		v[i] = v[i] / norm
+	}
+
+	return v
+}
+
+func encodeVector(v []float64, elementType FieldDenseVectorElementType) interface{} {
+	switch elementType {
+	case FieldDenseVectorElementTypeByte:
+		r := make([]int, len(v))
+		for i, x := range v {
+			r[i] = int(math.Round(x * 127))
+		}
+
+		return r
+
+	case FieldDenseVectorElementTypeBit:
+		r := make([]int, len(v)/8)
+		for i := range r {
+			b := 0
+			for j := 0; j < 8; j++ {
+				if v[i*8+j] > 0 {
+					b |= 1 << (7 - j)
+				}
+			}
+
+			r[i] = int(int8(b))
+		}
+
+		return r
+
+	default:
+		r := make([]float64, len(v))
+		for i, x := range v {
+			r[i] = math.Round(x*1e6) / 1e6
+		}
+
+		return r
+	}
+}
+
+// FakeCompletion defines how completion suggestions are generated: the fake used for inputs, the values
+// picked for category contexts and the points used for geo contexts.
+type FakeCompletion struct {
+	Input      Fake
+	Categories []string
+	Location   FakeGeoPoint
+}
+
+func fakeCompletion(fake FakeCompletion, contexts []FieldCompletionContext) fakeFunc {
+	input, ok := fakeFuncs[string(fake.Input)]
+	if !ok {
+		return nil
+	}
+
+	return func() interface{} {
+		value := fmt.Sprint(input())
+
+		// Every word suffix is a separate input, so suggestions also match from the middle of a value.
+		inputs := []string{value}
+
+		words := strings.Fields(value)
+		for c := 1; c < len(words); c++ {
+			inputs = append(inputs, strings.Join(words[c:], " "))
+		}
+
+		r := map[string]interface{}{
+			"input":  inputs,
+			"weight": gofakeit.IntRange(1, 100),
+		}
+
+		ctx := map[string]interface{}{}
+
+		for _, c := range contexts {
+			if c.Path != "" {
+				continue
+			}
+
+			switch c.Type {
+			case FieldCompletionContextTypeCategory:
+				if len(fake.Categories) == 0 {
+					continue
+				}
+
+				ctx[c.Name] = []string{fake.Categories[gofakeit.IntRange(0, len(fake.Categories)-1)]}
+
+			case FieldCompletionContextTypeGeo:
+				if fake.Location == nil {
+					continue
+				}
+
+				p, ok := fake.Location().([]float64)
+				if !ok {
+					continue
+				}
+
+				ctx[c.Name] = map[string]interface{}{
+					"lat": p[1],
+					"lon": p[0],
+				}
+			}
+		}
+
+		if len(ctx) > 0 {
+			r["contexts"] = ctx
+		}
+
+		return r
+	}
+}
+
+// generator{} describes how a value of a single field is generated. Leaf fields carry a fake function,
+// while object and nested fields carry their children and, for nested fields, how many objects to emit.
+type generator struct {
+	fake        fakeFunc
+	children    map[string]generator
+	cardinality *FakeCardinality
+	join        *generatorJoin
+	dimension   bool
+	metric      FieldTimeSeriesMetric
+}
+
+// generatorJoin{} holds the relations used to generate the values of a join field: the top-level parent
+// relations and the child relations of every parent, both sorted so generated documents are stable.
+type generatorJoin struct {
+	roots     []string
+	relations map[string][]string
+}
+
+// newGeneratorJoin() keeps the relations reachable from a top-level parent. A relation is visited once, so
+// relations Elasticsearch would reject (e.g., a child with two parents, cycles) cannot loop the generation.
+func newGeneratorJoin(relations map[string][]string) *generatorJoin {
+	isChild := map[string]bool{}
+	for _, children := range relations {
+		for _, c := range children {
+			isChild[c] = true
+		}
+	}
+
+	r := &generatorJoin{
+		relations: map[string][]string{},
+	}
+
+	for p, children := range relations {
+		if !isChild[p] && len(children) > 0 {
+			r.roots = append(r.roots, p)
+		}
+	}
+	if len(r.roots) == 0 {
+		return nil
+	}
+
+	sort.Strings(r.roots)
+
+	visited := map[string]bool{}
+
+	var visit func(relation string)
+	visit = func(relation string) {
+		visited[relation] = true
+
+		children := append([]string{}, relations[relation]...)
+		sort.Strings(children)
+
+		for _, c := range children {
+			if visited[c] {
+				continue
+			}
+
+			r.relations[relation] = append(r.relations[relation], c)
+
+			visit(c)
+		}
+	}
+
+	for _, root := range r.roots {
+		visit(root)
+	}
+
+	return r
+}
+
+// FakeCardinality defines how many nested objects are generated for a single document.
+type FakeCardinality struct {
+	Min int
+	Max int
+}
+
+// pendingGenerator{} is the generator of a field, registered when its FieldFunc is called and held until the
+// caller places the field: NewFields() moves top-level fields into toGenerate, objects and nested fields claim
+// their children and multi-fields drop their sub-fields. Entries are keyed by the address of the property map
+// of the field, so fields sharing a name at different levels never collide; the entry keeps the map alive, so
+// the address cannot be reused while it is registered.
+type pendingGenerator struct {
+	props map[string]interface{}
+	g     generator
+}
+
+var (
+	toGenerate        = map[string]generator{}
+	pendingToGenerate = map[uintptr]pendingGenerator{}
+	toGenerateMu      sync.Mutex
+)
+
+// storeToGenerate() registers the generator of a field until the caller of its FieldFunc places the field.
+func storeToGenerate(props map[string]interface{}, g generator) {
+	toGenerateMu.Lock()
+	defer toGenerateMu.Unlock()
+
+	pendingToGenerate[reflect.ValueOf(props).Pointer()] = pendingGenerator{
+		props: props,
+		g:     g,
+	}
+}
+
+// inspectToGenerate() updates the generator of a field with mapping properties that affect generation.
+func inspectToGenerate(g generator, r map[string]interface{}) generator {
+	// Elasticsearch rejects documents that carry a value for a scripted field.
+	_, ok := r["script"]
+	if ok {
+		return generator{}
+	}
+
+	dimension, ok := r["time_series_dimension"].(bool)
+	if ok {
+		g.dimension = dimension
+	}
+
+	metric, ok := r["time_series_metric"].(FieldTimeSeriesMetric)
+	if ok {
+		g.metric = metric
+	}
+
+	return g
+}
+
+// claimToGenerate() releases the registered generator of a field and returns it.
+func claimToGenerate(props map[string]interface{}) (generator, bool) {
+	if props == nil {
+		return generator{}, false
+	}
+
+	toGenerateMu.Lock()
+	defer toGenerateMu.Unlock()
+
+	key := reflect.ValueOf(props).Pointer()
+
+	p, ok := pendingToGenerate[key]
+	if !ok {
+		return generator{}, false
+	}
+
+	delete(pendingToGenerate, key)
+
+	return p.g, true
+}
+
+// promoteToGenerate() moves the registered generator of a top-level field into the generators of the document.
+func promoteToGenerate(name string, props map[string]interface{}) {
+	g, ok := claimToGenerate(props)
+	if !ok {
+		return
+	}
+
+	toGenerateMu.Lock()
+	defer toGenerateMu.Unlock()
+
+	toGenerate[name] = g
+}
+
+func snapshotToGenerate() map[string]generator {
+	toGenerateMu.Lock()
+	defer toGenerateMu.Unlock()
+
+	r := map[string]generator{}
+
+	for k, v := range toGenerate {
+		r[k] = v
+	}
 
-func storeToGenerate(name string, t string) {
-	toGenerate[name] = t
+	return r
 }
 
-func generateFakeData(t string) string {
-	fakeFuncs := map[string]fakeFunc{
-		string(FakeEmail):     fakeEmail(),
-		string(FakeFirstName): fakeFirstName(),
-		string(FakeLastName):  fakeLastName(),
-		string(FakeFullName):  fakeFullName(),
-		string(FakeUsername):  fakeUsername(),
-		string(FakePhone):     fakePhone(),
-		string(FakeCountry):   fakeCountry(),
-		string(FakeCity):      fakeCity(),
-		string(FakeStreet):    fakeStreet(),
-		string(FakeZip):       fakeZIP(),
-		string(FakeUUID):      fakeUUID(),
-		string(FakeURL):       fakeURL(),
-		string(FakeCompany):   fakeCompany(),
-		string(FakeJobTitle):  fakeJobTitle(),
-		string(FakeColor):     fakeColor(),
-		string(FakeIPv4):      fakeIPv4(),
-		string(FakeIPv6):      fakeIPv6(),
-		string(FakeBool):      fakeBoolean(),
-		string(FakeInt):       fakeInteger(),
-		string(FakeFloat):     fakeFloat64(),
-		string(FakeDate):      fakeDate(),
-		string(FakeTimestamp): fakeTimestamp(),
-		string(FakeParagraph): fakeParagraph(),
+func generateFakeObject(generators map[string]generator) map[string]interface{} {
+	r := map[string]interface{}{}
+
+	for k, v := range generators {
+		data, ok := generateFakeData(v)
+		if !ok {
+			continue
+		}
+
+		r[k] = data
 	}
 
-	fc := fakeFuncs[t]
+	return r
+}
+
+func generateFakeData(g generator) (interface{}, bool) {
+	switch {
+	case g.cardinality != nil:
+		max := g.cardinality.Max
+		if max < g.cardinality.Min {
+			max = g.cardinality.Min
+		}
+
+		objects := []interface{}{}
+
+		for c := gofakeit.IntRange(g.cardinality.Min, max); c > 0; c-- {
+			objects = append(objects, generateFakeObject(g.children))
+		}
+
+		return objects, true
+
+	case g.children != nil:
+		return generateFakeObject(g.children), true
+
+	case g.join != nil:
+		return g.join.roots[0], true
+
+	case g.fake != nil:
+		return g.fake(), true
+
+	default:
+		return nil, false
+	}
+}
 
-	return fc()
+var fakeFuncs = map[string]fakeFunc{
+	string(FakeEmail):     fakeEmail(),
+	string(FakeFirstName): fakeFirstName(),
+	string(FakeLastName):  fakeLastName(),
+	string(FakeFullName):  fakeFullName(),
+	string(FakeUsername):  fakeUsername(),
+	string(FakePhone):     fakePhone(),
+	string(FakeCountry):   fakeCountry(),
+	string(FakeCity):      fakeCity(),
+	string(FakeStreet):    fakeStreet(),
+	string(FakeZip):       fakeZIP(),
+	string(FakeUUID):      fakeUUID(),
+	string(FakeURL):       fakeURL(),
+	string(FakeCompany):   fakeCompany(),
+	string(FakeJobTitle):  fakeJobTitle(),
+	string(FakeColor):     fakeColor(),
+	string(FakeIPv4):      fakeIPv4(),
+	string(FakeIPv6):      fakeIPv6(),
+	string(FakeBool):      fakeBoolean(),
+	string(FakeInt):       fakeInteger(),
+	string(FakeFloat):     fakeFloat64(),
+	string(FakeDate):      fakeDate(),
+	string(FakeTimestamp): fakeTimestamp(),
+	string(FakeParagraph): fakeParagraph(),
+
+	string(FakeSparseVectorWords): fakeSparseVector(),
+
+	string(FakeWildcardLogLine): fakeLogLine(),
+
+	string(FakeUnsignedLongInt):    fakeUnsignedLong(),
+	string(FakeDateNanosTimestamp): fakeTimestampNanos(),
+	string(FakeBinaryBase64):       fakeBase64(),
+	string(FakeVersionSemver):      fakeSemver(),
+	string(FakeHistogramValues):    fakeHistogram(),
+	string(FakeRankFeatureFloat):   fakeRankFeature(),
+
+	string(FakeIntegerRangeInt): fakeIntegerRange(),
+	string(FakeFloatRangeFloat): fakeFloatRange(),
+	string(FakeIPRangeIPv4):     fakeIPRange(32, 8, 30),
+	string(FakeIPRangeIPv6):     fakeIPRange(128, 32, 120),
 }
 
-type fakeFunc func() string
+type fakeFunc func() interface{}
 
 func fakeEmail() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.Email()
 	}
 }
 
 func fakeFirstName() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.FirstName()
 	}
 }
 
 func fakeLastName() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.LastName()
 	}
 }
 func fakeFullName() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.Name()
 	}
 }
 
 func fakeUsername() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.Username()
 	}
 }
 
 func fakePhone() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.Phone()
 	}
 }
 
 func fakeCountry() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.Country()
 	}
 }
 
 func fakeCity() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.City()
 	}
 }
 
 func fakeStreet() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.Street()
 	}
 }
 
 func fakeZIP() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.Zip()
 	}
 }
 
 func fakeUUID() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.UUID()
 	}
 }
 
 func fakeURL() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.URL()
 	}
 }
 
 func fakeCompany() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.Company()
 	}
 }
 
 func fakeJobTitle() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.JobTitle()
 	}
 }
 
 func fakeColor() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.Color()
 	}
 }
 
 func fakeIPv4() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.IPv4Address()
 	}
 }
 
 func fakeIPv6() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.IPv6Address()
 	}
 }
 
 func fakeBoolean() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return fmt.Sprintf("%t", gofakeit.Bool())
 	}
 }
 
 func fakeInteger() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return fmt.Sprintf("%d", gofakeit.IntRange(0, 1000))
 	}
 }
 func fakeFloat64() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return fmt.Sprintf("%.2f", gofakeit.Float64Range(0.0, 1000.0))
 	}
 }
 
 func fakeDate() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.Date().Format("2006-01-02")
 	}
 }
 
 func fakeTimestamp() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.Date().Format(time.RFC3339)
 	}
 }
 
 func fakeParagraph() fakeFunc {
-	return func() string {
+	return func() interface{} {
 		return gofakeit.Paragraph(1, 3, 10, " ")
 	}
 }
+
+func fakeSparseVector() fakeFunc {
+	return func() interface{} {
+		r := map[string]float64{}
+
+		for c := gofakeit.IntRange(5, 15); c > 0; c-- {
+			r[strings.ToLower(gofakeit.Word())] = math.Round(gofakeit.Float64Range(0.01, 5)*1e4) / 1e4
+		}
+
+		return r
+	}
+}
+
+func fakeIntegerRange() fakeFunc {
+	return func() interface{} {
+		gte := gofakeit.IntRange(0, 1000)
+
+		return map[string]interface{}{
+			"gte": gte,
+			"lte": gte + gofakeit.IntRange(0, 1000),
+		}
+	}
+}
+
+func fakeFloatRange() fakeFunc {
+	return func() interface{} {
+		gte := gofakeit.Float64Range(0.0, 1000.0)
+		lte := gte + gofakeit.Float64Range(0.0, 1000.0)
+
+		return map[string]interface{}{
+			"gte": math.Round(gte*100) / 100,
+			"lte": math.Round(lte*100) / 100,
+		}
+	}
+}
+
+// fakeIPRange() generates network ranges in CIDR notation, with the host bits of the address cleared.
+func fakeIPRange(bits int, minPrefix int, maxPrefix int) fakeFunc {
+	return func() interface{} {
+		var ip net.IP
+
+		if bits == 32 {
+			ip = net.ParseIP(gofakeit.IPv4Address()).To4()
+		} else {
+			ip = net.ParseIP(gofakeit.IPv6Address())
+		}
+
+		prefix := gofakeit.IntRange(minPrefix, maxPrefix)
+
+		network := net.IPNet{
+			IP:   ip.Mask(net.CIDRMask(prefix, bits)),
+			Mask: net.CIDRMask(prefix, bits),
+		}
+
+		return network.String()
+	}
+}
+
+// fakeDateRange() generates date ranges rendered in the (first) format of the field, so that they are
+// accepted by Elasticsearch as is.
+func fakeDateRange(fake string, format string) fakeFunc {
+	if fake != string(FakeDateRangeDate) {
+		return nil
+	}
+
+	return func() interface{} {
+		gte := gofakeit.DateRange(time.Now().AddDate(-5, 0, 0), time.Now()).UTC()
+		lte := gte.Add(time.Duration(gofakeit.IntRange(0, 30*24)) * time.Hour)
+
+		return map[string]interface{}{
+			"gte": formatDate(gte, format),
+			"lte": formatDate(lte, format),
+		}
+	}
+}
+
+// formatDate() renders a time using an Elasticsearch date format: either a built-in format name or a
+// Java-style pattern. Only the first of several "||"-separated formats is used.
+func formatDate(t time.Time, format string) interface{} {
+	format = strings.TrimSpace(strings.Split(format, "||")[0])
+
+	switch format {
+	case "epoch_millis":
+		return t.UnixMilli()
+	case "epoch_second":
+		return t.Unix()
+	}
+
+	builtin := map[string]string{
+		"":                                "2006-01-02T15:04:05.000Z07:00",
+		"date_optional_time":              "2006-01-02T15:04:05.000Z07:00",
+		"strict_date_optional_time":       "2006-01-02T15:04:05.000Z07:00",
+		"strict_date_optional_time_nanos": "2006-01-02T15:04:05.000000000Z07:00",
+		"date_time":                       "2006-01-02T15:04:05.000Z07:00",
+		"strict_date_time":                "2006-01-02T15:04:05.000Z07:00",
+		"date_time_no_millis":             "2006-01-02T15:04:05Z07:00",
+		"strict_date_time_no_millis":      "2006-01-02T15:04:05Z07:00",
+		"date_hour_minute_second":         "2006-01-02T15:04:05",
+		"strict_date_hour_minute_second":  "2006-01-02T15:04:05",
+		"date_hour_minute":                "2006-01-02T15:04",
+		"strict_date_hour_minute":         "2006-01-02T15:04",
+		"date":                            "2006-01-02",
+		"strict_date":                     "2006-01-02",
+		"year_month_day":                  "2006-01-02",
+		"strict_year_month_day":           "2006-01-02",
+		"year_month":                      "2006-01",
+		"strict_year_month":               "2006-01",
+		"year":                            "2006",
+		"strict_year":                     "2006",
+		"basic_date":                      "20060102",
+		"basic_date_time":                 "20060102T150405.000Z0700",
+		"basic_date_time_no_millis":       "20060102T150405Z0700",
+	}
+
+	layout, ok := builtin[format]
+	if !ok {
+		layout = javaDateLayout(format)
+	}
+
+	return t.Format(layout)
+}
+
+// javaDateLayout() converts a Java DateTimeFormatter pattern (e.g. "yyyy-MM-dd HH:mm:ss") to a Go layout.
+func javaDateLayout(pattern string) string {
+	tokens := []struct {
+		java   string
+		layout string
+	}{
+		{"yyyy", "2006"}, {"uuuu", "2006"}, {"yy", "06"},
+		{"MMMM", "January"}, {"MMM", "Jan"}, {"MM", "01"},
+		{"dd", "02"}, {"EEEE", "Monday"}, {"EEE", "Mon"},
+		{"HH", "15"}, {"hh", "03"}, {"mm", "04"}, {"ss", "05"},
+		{"SSSSSSSSS", "000000000"}, {"SSSSSS", "000000"}, {"SSS", "000"},
+		{"XXX", "Z07:00"}, {"xxx", "-07:00"}, {"Z", "-0700"}, {"a", "PM"},
+		{"M", "1"}, {"d", "2"}, {"H", "15"}, {"h", "3"}, {"m", "4"}, {"s", "5"},
+	}
+
+	var b strings.Builder
+
+	for c := 0; c < len(pattern); {
+		if pattern[c] == '\'' {
+			end := strings.IndexByte(pattern[c+1:], '\'')
+			if end < 0 {
+				b.WriteString(pattern[c+1:])
+				break
+			}
+
+			b.WriteString(pattern[c+1 : c+1+end])
+			c += end + 2
+			continue
+		}
+
+		matched := false
+		for _, t := range tokens {
+			if strings.HasPrefix(pattern[c:], t.java) {
+				b.WriteString(t.layout)
+				c += len(t.java)
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			b.WriteByte(pattern[c])
+			c++
+		}
+	}
+
+	return b.String()
+}
+
+func fakeConstant(value string) fakeFunc {
+	return func() interface{} {
+		return value
+	}
+}
+
+func fakeLogLine() fakeFunc {
+	return func() interface{} {
+		return fmt.Sprintf(
+			"%s %s [%s] \"%s %s HTTP/1.1\" %d %d \"%s\"",
+			gofakeit.Date().Format(time.RFC3339),
+			gofakeit.IPv4Address(),
+			strings.ToUpper(gofakeit.LogLevel("general")),
+			gofakeit.HTTPMethod(),
+			"/"+strings.Join([]string{gofakeit.Word(), gofakeit.Word(), gofakeit.UUID()}, "/"),
+			gofakeit.HTTPStatusCode(),
+			gofakeit.IntRange(100, 100000),
+			gofakeit.UserAgent(),
+		)
+	}
+}
+
+// fakeFlattened() generates arbitrary objects of string values, nested no deeper than the depth limit
+// of the field (and never deeper than three levels, to keep documents small).
+func fakeFlattened(fake string, depthLimit int) fakeFunc {
+	if fake != string(FakeFlattenedObject) {
+		return nil
+	}
+
+	depth := depthLimit
+	if depth > 3 {
+		depth = 3
+	}
+
+	var object func(level int) map[string]interface{}
+
+	object = func(level int) map[string]interface{} {
+		r := map[string]interface{}{}
+
+		for c := gofakeit.IntRange(1, 4); c > 0; c-- {
+			key := strings.ToLower(gofakeit.Word())
+
+			if level < depth && gofakeit.Bool() {
+				r[key] = object(level + 1)
+				continue
+			}
+
+			r[key] = gofakeit.Word()
+		}
+
+		return r
+	}
+
+	return func() interface{} {
+		return object(1)
+	}
+}
+
+func fakeUnsignedLong() fakeFunc {
+	return func() interface{} {
+		return gofakeit.Uint64()
+	}
+}
+
+func fakeTimestampNanos() fakeFunc {
+	return func() interface{} {
+		// date_nanos supports dates from 1970 to 2262 only, so values are generated after the epoch.
+		t := gofakeit.DateRange(time.Unix(0, 0), time.Now()).Add(time.Duration(gofakeit.IntRange(0, int(time.Second)-1)))
+
+		return t.UTC().Format("2006-01-02T15:04:05.000000000Z07:00")
+	}
+}
+
+func fakeBase64() fakeFunc {
+	return func() interface{} {
+		b := make([]byte, gofakeit.IntRange(8, 64))
+		for i := range b {
+			b[i] = byte(gofakeit.IntRange(0, 255))
+		}
+
+		return base64.StdEncoding.EncodeToString(b)
+	}
+}
+
+func fakeSemver() fakeFunc {
+	return func() interface{} {
+		v := fmt.Sprintf("%d.%d.%d", gofakeit.IntRange(0, 10), gofakeit.IntRange(0, 30), gofakeit.IntRange(0, 100))
+
+		if gofakeit.IntRange(0, 4) == 0 {
+			v += fmt.Sprintf("-%s.%d", gofakeit.RandomString([]string{"alpha", "beta", "rc"}), gofakeit.IntRange(1, 5))
+		}
+
+		return v
+	}
+}
+
+// fakeHistogram() generates pre-aggregated histograms: strictly increasing values with a positive count each.
+func fakeHistogram() fakeFunc {
+	return func() interface{} {
+		values := []float64{}
+		counts := []int{}
+
+		v := 0.0
+		for c := gofakeit.IntRange(3, 10); c > 0; c-- {
+			v += math.Round(gofakeit.Float64Range(0.1, 10.0)*100) / 100
+
+			values = append(values, math.Round(v*100)/100)
+			counts = append(counts, gofakeit.IntRange(1, 100))
+		}
+
+		return map[string]interface{}{
+			"values": values,
+			"counts": counts,
+		}
+	}
+}
+
+func fakeRankFeature() fakeFunc {
+	return func() interface{} {
+		return math.Round(gofakeit.Float64Range(0.01, 100.0)*100) / 100
+	}
+}
+
+// fakeAggregateMetricDouble() generates the configured sub-metrics so that they describe the same, coherent
+// set of values: min <= max and min * value_count <= sum <= max * value_count.
+func fakeAggregateMetricDouble(fake string, metrics []FieldAggregateMetric) fakeFunc {
+	if fake != string(FakeAggregateMetricDoubleMetrics) {
+		return nil
+	}
+
+	return func() interface{} {
+		min := math.Round(gofakeit.Float64Range(0.0, 500.0)*100) / 100
+		max := min + math.Round(gofakeit.Float64Range(0.0, 500.0)*100)/100
+		count := gofakeit.IntRange(1, 1000)
+		sum := math.Round(gofakeit.Float64Range(min, max)*float64(count)*100) / 100
+
+		all := map[FieldAggregateMetric]interface{}{
+			FieldAggregateMetricMin:        min,
+			FieldAggregateMetricMax:        max,
+			FieldAggregateMetricSum:        sum,
+			FieldAggregateMetricValueCount: count,
+		}
+
+		r := map[string]interface{}{}
+
+		for _, m := range metrics {
+			v, ok := all[m]
+			if !ok {
+				continue
+			}
+
+			r[string(m)] = v
+		}
+
+		return r
+	}
+}
@@ -0,0 +1,950 @@
+package porter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/*
+
+This file contains an offline emulator of the Elasticsearch analysis chain. It runs text through an
+analyzer of the index definition without a cluster, so token streams can be checked in fast unit tests.
+
+The emulator covers a subset of what porter can build:
+
+	analyzers:  standard, simple, whitespace, keyword, pattern, stop and custom
+	tokenizers: standard, letter, lowercase, whitespace, keyword and pattern
+	filters:    lowercase, uppercase, asciifolding, trim, stop, length, ngram and edge_ngram
+
+For that subset it produces the same terms, offsets, types and positions as Elasticsearch, with two
+approximations: the standard tokenizer follows the Unicode word break rules for letters, numbers,
+punctuation and CJK scripts without the dictionary-based segmentation of Southeast Asian scripts, and
+asciifolding folds Latin-1, Latin Extended-A and common punctuation only, and reports any other character
+Lucene may fold (e.g., other Latin letters, superscripts, fullwidth forms). Analyzers that use any other
+component (e.g., character filters, stemmers, synonyms) are not emulated; every such component is
+reported in the returned error instead.
+
+Offsets are counted in UTF-16 code units, like Elasticsearch does.
+
+*/
+
+var (
+	ErrEmulatorUndefined   = fmt.Errorf("emulator: analyzer is not defined in the index definition")
+	ErrEmulatorUnsupported = fmt.Errorf("emulator: analyzer uses components the offline emulator does not support")
+)
+
+// emulatedToken{} is a token flowing through the emulated analysis chain. Position increments are kept instead of positions, so removed tokens leave gaps like in Lucene.
+type emulatedToken struct {
+	term   string
+	start  int
+	end    int
+	kind   string
+	posInc int
+}
+
+type emulatedTokenizer func(text string) []emulatedToken
+type emulatedFilter func(tokens []emulatedToken) ([]emulatedToken, error)
+
+// emulate() runs text through an analyzer of the analysis settings, which can also be a built-in analyzer.
+func emulate(a *AnalysisConfig, analyzer string, text string) ([]Token, error) {
+	if a == nil {
+		a = &AnalysisConfig{}
+	}
+
+	definition, defined := a.Analyzer[analyzer].(map[string]interface{})
+	if !defined {
+		switch analyzer {
+		case "standard", "simple", "whitespace", "keyword", "pattern", "stop":
+			definition = map[string]interface{}{"type": analyzer}
+		default:
+			return nil, fmt.Errorf("%w [%s]", ErrEmulatorUndefined, analyzer)
+		}
+	}
+
+	tokenizer, filters, problems := emulatedAnalyzer(a, analyzer, definition)
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w [%s]", ErrEmulatorUnsupported, strings.Join(problems, ", "))
+	}
+
+	tokens := tokenizer(text)
+	for _, f := range filters {
+		var err error
+
+		tokens, err = f(tokens)
+		if err != nil {
+			return nil, fmt.Errorf("%w [%s]", ErrEmulatorUnsupported, err)
+		}
+	}
+
+	r := []Token{}
+	position := -1
+
+	for _, t := range tokens {
+		position += t.posInc
+
+		r = append(r, Token{
+			Term:        t.term,
+			StartOffset: t.start,
+			EndOffset:   t.end,
+			Type:        t.kind,
+			Position:    position,
+		})
+	}
+
+	return r, nil
+}
+
+// emulatedAnalyzer() expands an analyzer definition into its tokenizer and filters, along with every component the emulator does not support.
+func emulatedAnalyzer(a *AnalysisConfig, name string, r map[string]interface{}) (emulatedTokenizer, []emulatedFilter, []string) {
+	var problems []string
+
+	stop := func(fallback string) emulatedFilter {
+		if _, ok := r["stopwords_path"]; ok {
+			problems = append(problems, fmt.Sprintf("analyzer [%s]: stopwords_path", name))
+		}
+
+		words, err := emulatedStopwords(r["stopwords"], fallback)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("analyzer [%s]: %s", name, err))
+		}
+
+		return emulatedStop(words, false)
+	}
+
+	maxTokenLength := 255
+	if n, ok := numberOf(r["max_token_length"]); ok {
+		maxTokenLength = int(n)
+	}
+
+	switch t := fmt.Sprint(r["type"]); t {
+	case "standard":
+		return emulatedStandardTokenizer(maxTokenLength), []emulatedFilter{emulatedLowercase, stop("_none_")}, problems
+	case "simple":
+		return emulatedLetterTokenizer(maxTokenLength, true), nil, problems
+	case "whitespace":
+		return emulatedWhitespaceTokenizer(maxTokenLength), nil, problems
+	case "keyword":
+		return emulatedKeywordTokenizer, nil, problems
+	case "stop":
+		return emulatedLetterTokenizer(maxTokenLength, true), []emulatedFilter{stop("_english_")}, problems
+	case "pattern":
+		tokenizer, err := emulatedPatternTokenizer(r["pattern"], r["flags"], -1)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("analyzer [%s]: %s", name, err))
+		}
+
+		var filters []emulatedFilter
+
+		lowercase, ok := r["lowercase"].(bool)
+		if !ok || lowercase {
+			filters = append(filters, emulatedLowercase)
+		}
+
+		return tokenizer, append(filters, stop("_none_")), problems
+	case "custom":
+	default:
+		return nil, nil, []string{fmt.Sprintf("analyzer [%s] of type [%s]", name, t)}
+	}
+
+	for _, c := range stringsOf(r["char_filter"]) {
+		problems = append(problems, fmt.Sprintf("char_filter [%s]", c))
+	}
+
+	tokenizer, err := emulatedTokenizerOf(a, fmt.Sprint(r["tokenizer"]))
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	var filters []emulatedFilter
+
+	for _, f := range stringsOf(r["filter"]) {
+		filter, err := emulatedFilterOf(a, f)
+		if err != nil {
+			problems = append(problems, err.Error())
+
+			continue
+		}
+
+		filters = append(filters, filter)
+	}
+
+	return tokenizer, filters, problems
+}
+
+// emulatedTokenizerOf() resolves a tokenizer configured in the analysis settings or a built-in one.
+func emulatedTokenizerOf(a *AnalysisConfig, name string) (emulatedTokenizer, error) {
+	r, defined := a.Tokenizer[name].(map[string]interface{})
+	if !defined {
+		r = map[string]interface{}{"type": name}
+	}
+
+	maxTokenLength := 255
+	if n, ok := numberOf(r["max_token_length"]); ok {
+		maxTokenLength = int(n)
+	}
+
+	switch t := fmt.Sprint(r["type"]); t {
+	case "standard":
+		return emulatedStandardTokenizer(maxTokenLength), nil
+	case "letter":
+		return emulatedLetterTokenizer(maxTokenLength, false), nil
+	case "lowercase":
+		return emulatedLetterTokenizer(maxTokenLength, true), nil
+	case "whitespace":
+		return emulatedWhitespaceTokenizer(maxTokenLength), nil
+	case "keyword":
+		return emulatedKeywordTokenizer, nil
+	case "pattern":
+		group := -1
+		if n, ok := numberOf(r["group"]); ok {
+			group = int(n)
+		}
+
+		tokenizer, err := emulatedPatternTokenizer(r["pattern"], r["flags"], group)
+		if err != nil {
+			return nil, fmt.Errorf("tokenizer [%s]: %s", name, err)
+		}
+
+		return tokenizer, nil
+	default:
+		return nil, fmt.Errorf("tokenizer [%s]", name)
+	}
+}
+
+// emulatedFilterOf() resolves a token filter configured in the analysis settings or a built-in one.
+func emulatedFilterOf(a *AnalysisConfig, name string) (emulatedFilter, error) {
+	r, defined := a.Filter[name].(map[string]interface{})
+	if !defined {
+		r = map[string]interface{}{"type": name}
+	}
+
+	preserveOriginal, _ := r["preserve_original"].(bool)
+
+	gram := func(key string, fallback int) int {
+		if n, ok := numberOf(r[key]); ok {
+			return int(n)
+		}
+
+		return fallback
+	}
+
+	switch t := fmt.Sprint(r["type"]); t {
+	case "lowercase":
+		if _, ok := r["language"]; ok {
+			return nil, fmt.Errorf("filter [%s]: language", name)
+		}
+
+		return emulatedLowercase, nil
+	case "uppercase":
+		return emulatedUppercase, nil
+	case "trim":
+		return emulatedTrim, nil
+	case "asciifolding":
+		return emulatedASCIIFolding(name, preserveOriginal), nil
+	case "stop":
+		if _, ok := r["stopwords_path"]; ok {
+			return nil, fmt.Errorf("filter [%s]: stopwords_path", name)
+		}
+		// Trailing stopwords are always removed, which is the default of Elasticsearch.
+		if removeTrailing, ok := r["remove_trailing"].(bool); ok && !removeTrailing {
+			return nil, fmt.Errorf("filter [%s]: remove_trailing", name)
+		}
+
+		words, err := emulatedStopwords(r["stopwords"], "_english_")
+		if err != nil {
+			return nil, fmt.Errorf("filter [%s]: %s", name, err)
+		}
+
+		ignoreCase, _ := r["ignore_case"].(bool)
+
+		return emulatedStop(words, ignoreCase), nil
+	case "length":
+		return emulatedLength(gram("min", 0), gram("max", int(^uint32(0)>>1))), nil
+	case "ngram":
+		return emulatedNGram(gram("min_gram", 1), gram("max_gram", 2), preserveOriginal), nil
+	case "edge_ngram":
+		return emulatedEdgeNGram(gram("min_gram", 1), gram("max_gram", 2), preserveOriginal), nil
+	default:
+		return nil, fmt.Errorf("filter [%s]", name)
+	}
+}
+
+// TOKENIZERS
+
+// emulatedText{} holds the runes of the text and their UTF-16 offsets, the unit Elasticsearch reports offsets in.
+type emulatedText struct {
+	runes   []rune
+	offsets []int
+}
+
+func newEmulatedText(text string) emulatedText {
+	t := emulatedText{}
+	offset := 0
+
+	for _, r := range text {
+		t.runes = append(t.runes, r)
+		t.offsets = append(t.offsets, offset)
+
+		offset++
+		if r >= 0x10000 {
+			offset++
+		}
+	}
+
+	t.offsets = append(t.offsets, offset)
+
+	return t
+}
+
+// token() creates a token from the runes [from, to), split into chunks of at most maxLength runes like Lucene does for overlong tokens.
+func (t emulatedText) token(from int, to int, kind string, maxLength int, lowercase bool) []emulatedToken {
+	var r []emulatedToken
+
+	for from < to {
+		end := to
+		if maxLength > 0 && end-from > maxLength {
+			end = from + maxLength
+		}
+
+		term := string(t.runes[from:end])
+		if lowercase {
+			term = strings.Map(unicode.ToLower, term)
+		}
+
+		r = append(r, emulatedToken{
+			term:   term,
+			start:  t.offsets[from],
+			end:    t.offsets[end],
+			kind:   kind,
+			posInc: 1,
+		})
+
+		from = end
+	}
+
+	return r
+}
+
+func emulatedKeywordTokenizer(text string) []emulatedToken {
+	if text == "" {
+		return nil
+	}
+
+	t := newEmulatedText(text)
+
+	return t.token(0, len(t.runes), "word", 0, false)
+}
+
+func emulatedLetterTokenizer(maxTokenLength int, lowercase bool) emulatedTokenizer {
+	return func(text string) []emulatedToken {
+		return emulatedRuns(text, unicode.IsLetter, maxTokenLength, lowercase)
+	}
+}
+
+func emulatedWhitespaceTokenizer(maxTokenLength int) emulatedTokenizer {
+	return func(text string) []emulatedToken {
+		return emulatedRuns(text, func(r rune) bool { return !isJavaWhitespace(r) }, maxTokenLength, false)
+	}
+}
+
+// emulatedRuns() emits the maximal runs of runes accepted by keep, the way Lucene's CharTokenizer does.
+func emulatedRuns(text string, keep func(r rune) bool, maxTokenLength int, lowercase bool) []emulatedToken {
+	t := newEmulatedText(text)
+
+	var r []emulatedToken
+
+	start := -1
+	for i, c := range t.runes {
+		if keep(c) {
+			if start < 0 {
+				start = i
+			}
+
+			continue
+		}
+
+		if start >= 0 {
+			r = append(r, t.token(start, i, "word", maxTokenLength, lowercase)...)
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		r = append(r, t.token(start, len(t.runes), "word", maxTokenLength, lowercase)...)
+	}
+
+	return r
+}
+
+// isJavaWhitespace() mirrors Java's Character.isWhitespace(), which excludes non-breaking spaces.
+func isJavaWhitespace(r rune) bool {
+	switch r {
+	case '\u00A0', '\u2007', '\u202F':
+		return false
+	case '\t', '\n', '\v', '\f', '\r', '\u001C', '\u001D', '\u001E', '\u001F':
+		return true
+	}
+
+	return unicode.In(r, unicode.Zs, unicode.Zl, unicode.Zp)
+}
+
+// emulatedPatternTokenizer() splits the text on a Java regular expression (group -1) or emits the given capture group of every match.
+func emulatedPatternTokenizer(pattern interface{}, flags interface{}, group int) (emulatedTokenizer, error) {
+	p := `\W+`
+	if pattern != nil {
+		p = fmt.Sprint(pattern)
+	}
+
+	inline := ""
+
+	if flags != nil && fmt.Sprint(flags) != "" {
+		for _, f := range strings.Split(fmt.Sprint(flags), "|") {
+			switch AnalyzerPatternFlags(strings.TrimSpace(f)) {
+			case AnalyzerPatternFlagsCaseInsensitive:
+				inline += "i"
+			case AnalyzerPatternFlagsMultiline:
+				inline += "m"
+			case AnalyzerPatternFlagsDotAll:
+				inline += "s"
+			case AnalyzerPatternFlagsUnicodeCase:
+				// Case-insensitive matching of Go regular expressions is Unicode-aware already.
+			default:
+				return nil, fmt.Errorf("flag [%s]", strings.TrimSpace(f))
+			}
+		}
+	}
+
+	if inline != "" {
+		p = "(?" + inline + ")" + p
+	}
+
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return nil, fmt.Errorf("pattern [%s]", pattern)
+	}
+
+	if group < -1 || group > re.NumSubexp() {
+		return nil, fmt.Errorf("group [%d]", group)
+	}
+
+	return func(text string) []emulatedToken {
+		// Regular expressions work on bytes, so their indexes are translated into UTF-16 offsets.
+		offsets := make([]int, len(text)+1)
+		offset := 0
+
+		for i, r := range text {
+			offsets[i] = offset
+
+			offset++
+			if r >= 0x10000 {
+				offset++
+			}
+
+			for b := i + 1; b < i+utf8.RuneLen(r); b++ {
+				offsets[b] = offset
+			}
+		}
+
+		offsets[len(text)] = offset
+
+		var r []emulatedToken
+
+		emit := func(from int, to int) {
+			if from >= to {
+				return
+			}
+
+			r = append(r, emulatedToken{
+				term:   text[from:to],
+				start:  offsets[from],
+				end:    offsets[to],
+				kind:   "word",
+				posInc: 1,
+			})
+		}
+
+		matches := re.FindAllStringSubmatchIndex(text, -1)
+
+		if group >= 0 {
+			for _, m := range matches {
+				if m[2*group] >= 0 {
+					emit(m[2*group], m[2*group+1])
+				}
+			}
+
+			return r
+		}
+
+		from := 0
+		for _, m := range matches {
+			emit(from, m[0])
+			from = m[1]
+		}
+		emit(from, len(text))
+
+		return r
+	}, nil
+}
+
+// STANDARD TOKENIZER
+
+type wordBreak int
+
+const (
+	wordBreakOther wordBreak = iota
+	wordBreakLetter
+	wordBreakNumeric
+	wordBreakExtendNumLet
+	wordBreakMidLetter
+	wordBreakMidNum
+	wordBreakMidNumLet
+	wordBreakExtend
+	wordBreakIdeographic
+	wordBreakHiragana
+	wordBreakKatakana
+	wordBreakHangul
+	wordBreakSoutheastAsian
+	wordBreakEmoji
+)
+
+// wordBreakOf() classifies a rune by its Unicode word break property (UAX #29), with the scripts the standard tokenizer types separately.
+func wordBreakOf(r rune) wordBreak {
+	switch r {
+	case ':', '\u00B7', '\u0387', '\u05F4', '\u2027', '\uFE13', '\uFE55', '\uFF1A':
+		return wordBreakMidLetter
+	case '.', '\'', '\u2018', '\u2019', '\u2024', '\uFE52', '\uFF07', '\uFF0E':
+		return wordBreakMidNumLet
+	case ',', ';', '\u037E', '\u0589', '\u060C', '\u060D', '\u066C', '\u07F8', '\u2044', '\uFE10', '\uFE14', '\uFE50', '\uFE54', '\uFF0C', '\uFF1B':
+		return wordBreakMidNum
+	case '\u200C', '\u200D', '\uFE0E', '\uFE0F':
+		return wordBreakExtend
+	case '\u30FC':
+		return wordBreakKatakana
+	}
+
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc), r >= 0x1F3FB && r <= 0x1F3FF:
+		return wordBreakExtend
+	case unicode.Is(unicode.Pc, r):
+		return wordBreakExtendNumLet
+	case unicode.Is(unicode.Han, r):
+		return wordBreakIdeographic
+	case unicode.Is(unicode.Hiragana, r):
+		return wordBreakHiragana
+	case unicode.Is(unicode.Katakana, r):
+		return wordBreakKatakana
+	case unicode.Is(unicode.Hangul, r):
+		return wordBreakHangul
+	case unicode.In(r, unicode.Thai, unicode.Lao, unicode.Myanmar, unicode.Khmer):
+		return wordBreakSoutheastAsian
+	case r >= 0x1F300 && r <= 0x1FAFF, r >= 0x2600 && r <= 0x27BF, r >= 0x1F1E6 && r <= 0x1F1FF:
+		return wordBreakEmoji
+	case unicode.Is(unicode.Nd, r):
+		return wordBreakNumeric
+	case unicode.IsLetter(r):
+		return wordBreakLetter
+	}
+
+	return wordBreakOther
+}
+
+// joinsWord() reports whether two adjacent classes belong to the same word (WB5, WB8-WB10, WB13-WB13b).
+func joinsWord(prev wordBreak, next wordBreak) bool {
+	word := func(c wordBreak) bool {
+		return c == wordBreakLetter || c == wordBreakNumeric
+	}
+
+	switch {
+	case word(prev) && word(next):
+		return true
+	case prev == wordBreakKatakana && next == wordBreakKatakana,
+		prev == wordBreakHangul && next == wordBreakHangul,
+		prev == wordBreakSoutheastAsian && next == wordBreakSoutheastAsian:
+		return true
+	case prev == wordBreakExtendNumLet && (word(next) || next == wordBreakKatakana || next == wordBreakExtendNumLet),
+		next == wordBreakExtendNumLet && (word(prev) || prev == wordBreakKatakana):
+		return true
+	}
+
+	return false
+}
+
+// emulatedStandardTokenizer() segments the text on Unicode word boundaries and emits the words, typed like Lucene's StandardTokenizer.
+func emulatedStandardTokenizer(maxTokenLength int) emulatedTokenizer {
+	return func(text string) []emulatedToken {
+		t := newEmulatedText(text)
+		classes := make([]wordBreak, len(t.runes))
+		for i, r := range t.runes {
+			classes[i] = wordBreakOf(r)
+		}
+
+		// next() skips the extend characters (WB4) that follow a rune.
+		next := func(i int) int {
+			for i < len(classes) && classes[i] == wordBreakExtend {
+				i++
+			}
+
+			return i
+		}
+
+		var r []emulatedToken
+
+		i := 0
+		for i < len(classes) {
+			c := classes[i]
+
+			switch c {
+			case wordBreakIdeographic, wordBreakHiragana:
+				end := next(i + 1)
+
+				kind := "<IDEOGRAPHIC>"
+				if c == wordBreakHiragana {
+					kind = "<HIRAGANA>"
+				}
+
+				r = append(r, t.token(i, end, kind, maxTokenLength, false)...)
+				i = end
+
+				continue
+			case wordBreakEmoji:
+				end := next(i + 1)
+				for end < len(classes) && t.runes[end-1] == '\u200D' && classes[end] == wordBreakEmoji {
+					end = next(end + 1)
+				}
+				if end < len(classes) && t.runes[i] >= 0x1F1E6 && t.runes[i] <= 0x1F1FF && t.runes[end] >= 0x1F1E6 && t.runes[end] <= 0x1F1FF {
+					end = next(end + 1)
+				}
+
+				r = append(r, t.token(i, end, "<EMOJI>", maxTokenLength, false)...)
+				i = end
+
+				continue
+			case wordBreakLetter, wordBreakNumeric, wordBreakExtendNumLet, wordBreakKatakana, wordBreakHangul, wordBreakSoutheastAsian:
+			default:
+				i++
+
+				continue
+			}
+
+			start := i
+			last := c
+			seen := map[wordBreak]bool{c: true}
+
+			j := next(i + 1)
+			for j < len(classes) {
+				cj := classes[j]
+
+				if joinsWord(last, cj) {
+					last = cj
+					seen[cj] = true
+					j = next(j + 1)
+
+					continue
+				}
+
+				// WB6/WB7 and WB11/WB12: a single separator between two letters or two numbers.
+				k := next(j + 1)
+				if k < len(classes) {
+					letters := last == wordBreakLetter && classes[k] == wordBreakLetter && (cj == wordBreakMidLetter || cj == wordBreakMidNumLet)
+					numbers := last == wordBreakNumeric && classes[k] == wordBreakNumeric && (cj == wordBreakMidNum || cj == wordBreakMidNumLet)
+
+					if letters || numbers {
+						j = k
+
+						continue
+					}
+				}
+
+				break
+			}
+
+			kind := ""
+			switch {
+			case seen[wordBreakHangul]:
+				kind = "<HANGUL>"
+			case seen[wordBreakKatakana]:
+				kind = "<KATAKANA>"
+			case seen[wordBreakSoutheastAsian]:
+				kind = "<SOUTHEAST_ASIAN>"
+			case seen[wordBreakLetter]:
+				kind = "<ALPHANUM>"
+			case seen[wordBreakNumeric]:
+				kind = "<NUM>"
+			}
+
+			// Words made of connector punctuation only (e.g., "___") are not emitted.
+			if kind != "" {
+				r = append(r, t.token(start, j, kind, maxTokenLength, false)...)
+			}
+
+			i = j
+		}
+
+		return r
+	}
+}
+
+// FILTERS
+
+func emulatedMap(fn func(r rune) rune) emulatedFilter {
+	return func(tokens []emulatedToken) ([]emulatedToken, error) {
+		for i := range tokens {
+			tokens[i].term = strings.Map(fn, tokens[i].term)
+		}
+
+		return tokens, nil
+	}
+}
+
+var (
+	emulatedLowercase = emulatedMap(unicode.ToLower)
+	emulatedUppercase = emulatedMap(unicode.ToUpper)
+)
+
+// emulatedTrim() trims whitespace from the terms. Like Lucene's TrimFilter, it keeps the offsets of the untrimmed token.
+func emulatedTrim(tokens []emulatedToken) ([]emulatedToken, error) {
+	for i := range tokens {
+		tokens[i].term = strings.TrimFunc(tokens[i].term, isJavaWhitespace)
+	}
+
+	return tokens, nil
+}
+
+// emulatedRemove() drops the tokens rejected by keep and moves their position increments onto the next kept token.
+func emulatedRemove(keep func(t emulatedToken) bool) emulatedFilter {
+	return func(tokens []emulatedToken) ([]emulatedToken, error) {
+		var r []emulatedToken
+
+		skipped := 0
+		for _, t := range tokens {
+			if !keep(t) {
+				skipped += t.posInc
+
+				continue
+			}
+
+			t.posInc += skipped
+			skipped = 0
+
+			r = append(r, t)
+		}
+
+		return r, nil
+	}
+}
+
+// englishStopwords is Lucene's default English stop word set, used by the stop filter and analyzer.
+var englishStopwords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "if", "in", "into", "is", "it", "no", "not", "of",
+	"on", "or", "such", "that", "the", "their", "then", "there", "these", "they", "this", "to", "was", "will", "with",
+}
+
+// emulatedStopwords() reads stopwords set as a list or as a predefined list name. Only the _english_ and _none_ lists are known to the emulator.
+func emulatedStopwords(v interface{}, fallback string) ([]string, error) {
+	if v == nil {
+		v = fallback
+	}
+
+	if s, ok := v.(string); ok {
+		switch s {
+		case "_english_":
+			return englishStopwords, nil
+		case "_none_":
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("stopwords [%s]", s)
+		}
+	}
+
+	return stringsOf(v), nil
+}
+
+func emulatedStop(words []string, ignoreCase bool) emulatedFilter {
+	set := map[string]bool{}
+	for _, w := range words {
+		if ignoreCase {
+			w = strings.ToLower(w)
+		}
+
+		set[w] = true
+	}
+
+	return emulatedRemove(func(t emulatedToken) bool {
+		term := t.term
+		if ignoreCase {
+			term = strings.ToLower(term)
+		}
+
+		return !set[term]
+	})
+}
+
+// emulatedLength() keeps the tokens whose length, in UTF-16 code units like Lucene counts it, is within [min, max].
+func emulatedLength(min int, max int) emulatedFilter {
+	return emulatedRemove(func(t emulatedToken) bool {
+		length := 0
+		for _, r := range t.term {
+			length++
+			if r >= 0x10000 {
+				length++
+			}
+		}
+
+		return length >= min && length <= max
+	})
+}
+
+// emulatedNGram() emits the n-grams of every token, ordered by start and then by length, with the offsets of the original token.
+func emulatedNGram(min int, max int, preserveOriginal bool) emulatedFilter {
+	return func(tokens []emulatedToken) ([]emulatedToken, error) {
+		var r []emulatedToken
+
+		posInc := 0
+		for _, t := range tokens {
+			posInc += t.posInc
+
+			runes := []rune(t.term)
+			if preserveOriginal && len(runes) < min {
+				t.posInc = posInc
+				posInc = 0
+
+				r = append(r, t)
+
+				continue
+			}
+
+			for start := 0; start < len(runes); start++ {
+				for size := min; size <= max && start+size <= len(runes); size++ {
+					gram := t
+					gram.term = string(runes[start : start+size])
+					gram.posInc = posInc
+					posInc = 0
+
+					r = append(r, gram)
+				}
+			}
+
+			if preserveOriginal && len(runes) > max {
+				t.posInc = 0
+
+				r = append(r, t)
+			}
+		}
+
+		return r, nil
+	}
+}
+
+// emulatedEdgeNGram() emits the prefixes of every token from min to max characters, with the offsets of the original token.
+func emulatedEdgeNGram(min int, max int, preserveOriginal bool) emulatedFilter {
+	return func(tokens []emulatedToken) ([]emulatedToken, error) {
+		var r []emulatedToken
+
+		posInc := 0
+		for _, t := range tokens {
+			posInc += t.posInc
+
+			runes := []rune(t.term)
+			if preserveOriginal && len(runes) < min {
+				t.posInc = posInc
+				posInc = 0
+
+				r = append(r, t)
+
+				continue
+			}
+
+			for size := min; size <= max && size <= len(runes); size++ {
+				gram := t
+				gram.term = string(runes[:size])
+				gram.posInc = posInc
+				posInc = 0
+
+				r = append(r, gram)
+			}
+
+			if preserveOriginal && len(runes) > max {
+				t.posInc = 0
+
+				r = append(r, t)
+			}
+		}
+
+		return r, nil
+	}
+}
+
+// emulatedASCIIFolding() folds characters into their ASCII equivalents. With preserveOriginal, the folded token is followed by the original one at the same position.
+// Characters Lucene would fold but the emulator's table does not know are reported instead of being kept as they are.
+func emulatedASCIIFolding(name string, preserveOriginal bool) emulatedFilter {
+	return func(tokens []emulatedToken) ([]emulatedToken, error) {
+		var r []emulatedToken
+
+		for _, t := range tokens {
+			var b strings.Builder
+			for _, c := range t.term {
+				folded, ok := asciiFolding[c]
+				if ok {
+					b.WriteString(folded)
+				} else if isUnmappedFolding(c) {
+					return nil, fmt.Errorf("filter [%s]: character [%U]", name, c)
+				} else {
+					b.WriteRune(c)
+				}
+			}
+
+			folded := t
+			folded.term = b.String()
+
+			r = append(r, folded)
+
+			if preserveOriginal && folded.term != t.term {
+				t.posInc = 0
+
+				r = append(r, t)
+			}
+		}
+
+		return r, nil
+	}
+}
+
+// isUnmappedFolding() reports whether Lucene's ASCIIFoldingFilter may fold a character missing from asciiFolding: non-ASCII Latin letters, other numbers (e.g., superscripts, circled digits) and fullwidth ASCII forms.
+func isUnmappedFolding(r rune) bool {
+	if r <= unicode.MaxASCII {
+		return false
+	}
+
+	return unicode.Is(unicode.Latin, r) || unicode.Is(unicode.No, r) || (r >= '\uFF01' && r <= '\uFF5E')
+}
+
+// asciiFolding maps the characters of Latin-1, Latin Extended-A and common punctuation to their ASCII equivalents, as Lucene's ASCIIFoldingFilter does.
+var asciiFolding = func() map[rune]string {
+	groups := map[string]string{
+		"ÀÁÂÃÄÅĀĂĄ": "A", "àáâãäåāăąª": "a", "Æ": "AE", "æ": "ae",
+		"ÇĆĈĊČ": "C", "çćĉċč": "c", "ÐĎĐ": "D", "ðďđ": "d",
+		"ÈÉÊËĒĔĖĘĚ": "E", "èéêëēĕėęě": "e", "ĜĞĠĢ": "G", "ĝğġģ": "g",
+		"ĤĦ": "H", "ĥħ": "h", "ÌÍÎÏĨĪĬĮİ": "I", "ìíîïĩīĭįı": "i",
+		"Ĳ": "IJ", "ĳ": "ij", "Ĵ": "J", "ĵ": "j", "Ķ": "K", "ķ": "k", "ĸ": "q",
+		"ĹĻĽĿŁ": "L", "ĺļľŀł": "l", "ÑŃŅŇŊ": "N", "ñńņňŋ": "n", "ŉ": "'n",
+		"ÒÓÔÕÖØŌŎŐ": "O", "òóôõöøōŏőº": "o", "Œ": "OE", "œ": "oe",
+		"ŔŖŘ": "R", "ŕŗř": "r", "ŚŜŞŠ": "S", "śŝşšſ": "s", "ß": "ss",
+		"ŢŤŦ": "T", "ţťŧ": "t", "Þ": "TH", "þ": "th",
+		"ÙÚÛÜŨŪŬŮŰŲ": "U", "ùúûüũūŭůűų": "u", "Ŵ": "W", "ŵ": "w",
+		"ÝŶŸ": "Y", "ýÿŷ": "y", "ŹŻŽ": "Z", "źżž": "z",
+		"‘’‚‛′": "'", "“”„‟″«»": "\"", "‐‑‒–—―": "-", "…": "...",
+		"¡": "!", "¿": "?", "¹": "1", "²": "2", "³": "3",
+	}
+
+	r := map[rune]string{}
+	for chars, folded := range groups {
+		for _, c := range chars {
+			r[c] = folded
+		}
+	}
+
+	return r
+}()
@@ -11,28 +11,67 @@ functions. Each field type has a corresponding factory function that returns a f
 field definitions with customizable properties. These properties can be added dynamically using functional
 composition.
 
+Object and nested fields accept child fields through WithProperties(). Children are detached from the
+top level of generated documents and are generated inside their parent instead, recursively.
+
+A field is only generated once it is placed: a FieldFunc must be passed to NewFields(), to WithProperties()
+of an object or nested field, or to WithFields() as a multi-field. Calling a FieldFunc directly returns its
+mapping, but its fake is not generated in documents.
+
 The goal of this package is to provide an easy and extensible way to generate realistic, test-ready data
 for Elasticsearch systems, ensuring compatibility with Elasticsearch's field mappings and type constraints.
 
 */
 
 type fields struct {
-	Keyword     FieldKeyword
-	Text        FieldText
-	Integer     FieldInteger
-	Long        FieldLong
-	Float       FieldFloat
-	Double      FieldDouble
-	Short       FieldShort
-	Byte        FieldByte
-	HalfFloat   FieldHalfFloat
-	ScaledFloat FieldScaledFloat
-	Date        FieldDate
-	Boolean     FieldBoolean
-	IP          FieldIP
-}
-
-// NewFields() generates a map of field names and their corresponding values.
+	Keyword               FieldKeyword
+	Text                  FieldText
+	Integer               FieldInteger
+	Long                  FieldLong
+	Float                 FieldFloat
+	Double                FieldDouble
+	Short                 FieldShort
+	Byte                  FieldByte
+	HalfFloat             FieldHalfFloat
+	ScaledFloat           FieldScaledFloat
+	Date                  FieldDate
+	Boolean               FieldBoolean
+	IP                    FieldIP
+	Object                FieldObject
+	Nested                FieldNested
+	GeoPoint              FieldGeoPoint
+	GeoShape              FieldGeoShape
+	Point                 FieldPoint
+	Shape                 FieldShape
+	DenseVector           FieldDenseVector
+	SparseVector          FieldSparseVector
+	RankFeatures          FieldRankFeatures
+	Completion            FieldCompletion
+	SearchAsYouType       FieldSearchAsYouType
+	IntegerRange          FieldIntegerRange
+	LongRange             FieldLongRange
+	FloatRange            FieldFloatRange
+	DoubleRange           FieldDoubleRange
+	DateRange             FieldDateRange
+	IPRange               FieldIPRange
+	Wildcard              FieldWildcard
+	ConstantKeyword       FieldConstantKeyword
+	Flattened             FieldFlattened
+	MatchOnlyText         FieldMatchOnlyText
+	Join                  FieldJoin
+	UnsignedLong          FieldUnsignedLong
+	DateNanos             FieldDateNanos
+	Binary                FieldBinary
+	Version               FieldVersion
+	TokenCount            FieldTokenCount
+	Histogram             FieldHistogram
+	AggregateMetricDouble FieldAggregateMetricDouble
+	RankFeature           FieldRankFeature
+	Percolator            FieldPercolator
+	Alias                 FieldAlias
+}
+
+// NewFields() generates a map of field names and their corresponding values. The fields become the top-level fields of generated documents; fields built without it are not generated.
 func (m mappings) NewFields(fields ...FieldFunc) map[string]interface{} {
 	r := map[string]interface{}{}
 
@@ -41,6 +80,9 @@ func (m mappings) NewFields(fields ...FieldFunc) map[string]interface{} {
 			continue
 		}
 		for k, v := range fn() {
+			props, _ := v.(map[string]interface{})
+			promoteToGenerate(k, props)
+
 			r[k] = v
 		}
 	}
@@ -50,6 +92,101 @@ func (m mappings) NewFields(fields ...FieldFunc) map[string]interface{} {
 
 type FieldFunc func() map[string]interface{}
 
+// FieldMeta{} holds the metadata of a field. Elasticsearch accepts at most 5 entries, with keys up to 20 and values up to 50 characters.
+type FieldMeta struct {
+	Unit       string
+	MetricType FieldTimeSeriesMetric
+	Custom     map[string]string
+}
+
+func (m FieldMeta) properties() map[string]string {
+	r := map[string]string{}
+
+	for k, v := range m.Custom {
+		r[k] = v
+	}
+
+	if m.Unit != "" {
+		r["unit"] = m.Unit
+	}
+	if m.MetricType != "" {
+		r["metric_type"] = string(m.MetricType)
+	}
+
+	return r
+}
+
+// FieldOnScriptError defines what happens when the script of an indexed field fails.
+type FieldOnScriptError string
+
+var (
+	FieldOnScriptErrorFail     FieldOnScriptError = "fail"
+	FieldOnScriptErrorContinue FieldOnScriptError = "continue"
+)
+
+// FieldTimeSeriesMetric defines the metric type of a field in a time series data stream.
+type FieldTimeSeriesMetric string
+
+var (
+	FieldTimeSeriesMetricGauge   FieldTimeSeriesMetric = "gauge"
+	FieldTimeSeriesMetricCounter FieldTimeSeriesMetric = "counter"
+)
+
+func newChildren(fields []FieldFunc) map[string]interface{} {
+	r := map[string]interface{}{}
+
+	for _, fn := range fields {
+		if fn == nil {
+			continue
+		}
+		for k, v := range fn() {
+			r[k] = v
+		}
+	}
+
+	return r
+}
+
+func claimChildren(r map[string]interface{}) map[string]generator {
+	children := map[string]generator{}
+
+	p, ok := r["properties"].(map[string]interface{})
+	if !ok {
+		return children
+	}
+
+	for k, v := range p {
+		props, _ := v.(map[string]interface{})
+
+		g, ok := claimToGenerate(props)
+		if !ok {
+			continue
+		}
+
+		children[k] = g
+	}
+
+	return children
+}
+
+func newMultiFields(fields []FieldFunc) map[string]interface{} {
+	r := map[string]interface{}{}
+
+	for _, fn := range fields {
+		if fn == nil {
+			continue
+		}
+		for k, v := range fn() {
+			props, _ := v.(map[string]interface{})
+			claimToGenerate(props)
+
+			r[k] = v
+		}
+	}
+
+	return r
+}
+
 // KEYWORD
 
 type FieldKeywordProperties func() map[string]interface{}
@@ -57,10 +194,10 @@ type FieldKeyword func(name string, fake Fake, properties ...FieldKeywordPropert
 
 func newFieldKeyword() FieldKeyword {
 	return func(name string, fake Fake, properties ...FieldKeywordProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -70,9 +207,13 @@ func newFieldKeyword() FieldKeyword {
 			}
 		}
 
+		g = inspectToGenerate(g, r)
+
 		r["type"] = "keyword"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -98,7 +239,14 @@ func (k FieldKeyword) WithEagerGlobalOrdinals(enabled bool) FieldKeywordProperti
 	}
 }
 
-/* TODO: WithField(...) */
+// WithFields() adds a "fields" property (multi-fields) to a FieldKeyword. Sub-fields are indexed from the parent value, so they are never generated on their own.
+func (k FieldKeyword) WithFields(fields ...FieldFunc) FieldKeywordProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"fields": newMultiFields(fields),
+		}
+	}
+}
 
 // WithIgnoreAbove() adds an "ignore_above" property to a FieldKeyword.
 func (k FieldKeyword) WithIgnoreAbove(value int) FieldKeywordProperties {
@@ -120,7 +268,23 @@ func (k FieldKeyword) WithIndex(enabled bool) FieldKeywordProperties {
 
 /* TODO: WithIndexOptions(...) */
 
-/* TODO: WithMeta(...) */
+// WithCopyTo() adds a "copy_to" property to a FieldKeyword, copying its value into one or more target fields.
+func (k FieldKeyword) WithCopyTo(targets ...string) FieldKeywordProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldKeyword.
+func (k FieldKeyword) WithMeta(value FieldMeta) FieldKeywordProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
 
 /* TODO: WithNorms(...) */
 
@@ -133,9 +297,23 @@ func (k FieldKeyword) WithNullValue(value string) FieldKeywordProperties {
 	}
 }
 
-/* TODO: WithOnScriptError(...) */
+// WithOnScriptError() adds an "on_script_error" property to a FieldKeyword. It only applies together with WithScript().
+func (k FieldKeyword) WithOnScriptError(value FieldOnScriptError) FieldKeywordProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
 
-/* TODO: WithScript(...) */
+// WithScript() adds a "script" property to a FieldKeyword. Values of scripted fields are computed at index time, so they are never generated.
+func (k FieldKeyword) WithScript(value ScriptConfig) FieldKeywordProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
 
 // WithStore() adds a "store" property to a FieldKeyword.
 func (k FieldKeyword) WithStore(enabled bool) FieldKeywordProperties {
@@ -146,7 +324,14 @@ func (k FieldKeyword) WithStore(enabled bool) FieldKeywordProperties {
 	}
 }
 
-/* TODO: WithSimilarity(...) */
+// WithSimilarity() adds a "similarity" property to a FieldKeyword, referencing a built-in similarity or one defined in SettingsConfig.Similarity.
+func (k FieldKeyword) WithSimilarity(value string) FieldKeywordProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"similarity": value,
+		}
+	}
+}
 
 // WithNormalizer() adds a "normalizer" property to a FieldKeyword.
 func (k FieldKeyword) WithNormalizer(value string) FieldKeywordProperties {
@@ -159,19 +344,43 @@ func (k FieldKeyword) WithNormalizer(value string) FieldKeywordProperties {
 
 /* TODO: WithSplitQueriesOnWhitespace(...) */
 
-/* TODO: WithTimeSeriesDimension(...) */
+// WithTimeSeriesDimension() adds a "time_series_dimension" property to a FieldKeyword. Generated time series keep dimension values stable.
+func (k FieldKeyword) WithTimeSeriesDimension(enabled bool) FieldKeywordProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_dimension": enabled,
+		}
+	}
+}
 
 // TEXT
 
+// FieldIndexOptions defines which information is added to the inverted index of a field.
+type FieldIndexOptions string
+
+var (
+	FieldIndexOptionsDocs      FieldIndexOptions = "docs"
+	FieldIndexOptionsFreqs     FieldIndexOptions = "freqs"
+	FieldIndexOptionsPositions FieldIndexOptions = "positions"
+	FieldIndexOptionsOffsets   FieldIndexOptions = "offsets"
+)
+
+// FieldTextFrequencyFilter{} limits which terms are loaded into fielddata by their document frequency.
+type FieldTextFrequencyFilter struct {
+	Min            float64 `json:"min,omitempty"`
+	Max            float64 `json:"max,omitempty"`
+	MinSegmentSize int     `json:"min_segment_size,omitempty"`
+}
+
 type FieldTextProperties func() map[string]interface{}
 type FieldText func(name string, fake Fake, properties ...FieldTextProperties) FieldFunc
 
 func newFieldText() FieldText {
 	return func(name string, fake Fake, properties ...FieldTextProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -184,6 +393,8 @@ func newFieldText() FieldText {
 		r["type"] = "text"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -209,11 +420,32 @@ func (t FieldText) WithEagerGlobalOrdinals(enabled bool) FieldTextProperties {
 	}
 }
 
-/* TODO: WithFieldData(...) */
+// WithFieldData() adds a "fielddata" property to a FieldText.
+func (t FieldText) WithFieldData(enabled bool) FieldTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"fielddata": enabled,
+		}
+	}
+}
 
-/* TODO: WithFieldDataFrequencyFilter(...) */
+// WithFieldDataFrequencyFilter() adds a "fielddata_frequency_filter" property to a FieldText. It only applies together with WithFieldData().
+func (t FieldText) WithFieldDataFrequencyFilter(value FieldTextFrequencyFilter) FieldTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"fielddata_frequency_filter": value,
+		}
+	}
+}
 
-/* TODO: WithField(...) */
+// WithFields() adds a "fields" property (multi-fields) to a FieldText. Sub-fields are indexed from the parent value, so they are never generated on their own.
+func (t FieldText) WithFields(fields ...FieldFunc) FieldTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"fields": newMultiFields(fields),
+		}
+	}
+}
 
 // WithIndex() adds an "index" property to a FieldText.
 func (t FieldText) WithIndex(enabled bool) FieldTextProperties {
@@ -224,11 +456,35 @@ func (t FieldText) WithIndex(enabled bool) FieldTextProperties {
 	}
 }
 
-/* TODO: WithIndexOptions(...) */
+// WithIndexOptions() adds an "index_options" property to a FieldText.
+func (t FieldText) WithIndexOptions(value FieldIndexOptions) FieldTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index_options": value,
+		}
+	}
+}
 
-/* TODO: WithIndexPrefixes(...) */
+// WithIndexPrefixes() adds an "index_prefixes" property to a FieldText, indexing prefixes between min and max characters long.
+func (t FieldText) WithIndexPrefixes(min int, max int) FieldTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index_prefixes": map[string]interface{}{
+				"min_chars": min,
+				"max_chars": max,
+			},
+		}
+	}
+}
 
-/* TODO: WithIndexPhrases(...) */
+// WithIndexPhrases() adds an "index_phrases" property to a FieldText.
+func (t FieldText) WithIndexPhrases(enabled bool) FieldTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index_phrases": enabled,
+		}
+	}
+}
 
 // WithNorms() adds a "norms" property to a FieldText.
 func (t FieldText) WithNorms(enabled bool) FieldTextProperties {
@@ -266,9 +522,23 @@ func (t FieldText) WithSearchAnalyzer(value string) FieldTextProperties {
 	}
 }
 
-/* TODO: WithSearchQuoteAnalyzer(...) */
+// WithSearchQuoteAnalyzer() adds a "search_quote_analyzer" property to a FieldText. It only applies together with WithSearchAnalyzer().
+func (t FieldText) WithSearchQuoteAnalyzer(value string) FieldTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"search_quote_analyzer": value,
+		}
+	}
+}
 
-/* TODO: WithSimilarity(...) */
+// WithSimilarity() adds a "similarity" property to a FieldText, referencing a built-in similarity or one defined in SettingsConfig.Similarity.
+func (t FieldText) WithSimilarity(value string) FieldTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"similarity": value,
+		}
+	}
+}
 
 // WithTermVector() adds a "term_vector" property to a FieldText.
 func (t FieldText) WithTermVector(value string) FieldTextProperties {
@@ -279,7 +549,23 @@ func (t FieldText) WithTermVector(value string) FieldTextProperties {
 	}
 }
 
-/* TODO: WithMeta(...) */
+// WithCopyTo() adds a "copy_to" property to a FieldText, copying its value into one or more target fields.
+func (t FieldText) WithCopyTo(targets ...string) FieldTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldText.
+func (t FieldText) WithMeta(value FieldMeta) FieldTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
 
 // INTEGER
 
@@ -288,10 +574,10 @@ type FieldInteger func(name string, fake FakeInteger, properties ...FieldInteger
 
 func newFieldInteger() FieldInteger {
 	return func(name string, fake FakeInteger, properties ...FieldIntegerProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -301,9 +587,13 @@ func newFieldInteger() FieldInteger {
 			}
 		}
 
+		g = inspectToGenerate(g, r)
+
 		r["type"] = "integer"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -347,7 +637,23 @@ func (i FieldInteger) WithIndex(enabled bool) FieldIntegerProperties {
 	}
 }
 
-/* TODO: WithMeta(...) */
+// WithCopyTo() adds a "copy_to" property to a FieldInteger, copying its value into one or more target fields.
+func (i FieldInteger) WithCopyTo(targets ...string) FieldIntegerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldInteger.
+func (i FieldInteger) WithMeta(value FieldMeta) FieldIntegerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
 
 // WithNullValue() adds a "null_value" property to an integer field.
 func (i FieldInteger) WithNullValue(value int) FieldIntegerProperties {
@@ -358,9 +664,23 @@ func (i FieldInteger) WithNullValue(value int) FieldIntegerProperties {
 	}
 }
 
-/* TODO: WithOnScriptError(...) */
+// WithOnScriptError() adds an "on_script_error" property to a FieldInteger. It only applies together with WithScript().
+func (i FieldInteger) WithOnScriptError(value FieldOnScriptError) FieldIntegerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
 
-/* TODO: WithScript(...) */
+// WithScript() adds a "script" property to a FieldInteger. Values of scripted fields are computed at index time, so they are never generated.
+func (i FieldInteger) WithScript(value ScriptConfig) FieldIntegerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
 
 // WithStore() adds a "store" property to an integer field.
 func (i FieldInteger) WithStore(enabled bool) FieldIntegerProperties {
@@ -371,7 +691,23 @@ func (i FieldInteger) WithStore(enabled bool) FieldIntegerProperties {
 	}
 }
 
-/* TODO: WithTimeSeriesDimension(...) */
+// WithTimeSeriesDimension() adds a "time_series_dimension" property to an integer field. Generated time series keep dimension values stable.
+func (i FieldInteger) WithTimeSeriesDimension(enabled bool) FieldIntegerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_dimension": enabled,
+		}
+	}
+}
+
+// WithTimeSeriesMetric() adds a "time_series_metric" property to an integer field. Generated time series keep counters monotonically increasing.
+func (i FieldInteger) WithTimeSeriesMetric(value FieldTimeSeriesMetric) FieldIntegerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_metric": value,
+		}
+	}
+}
 
 // LONG
 
@@ -380,10 +716,10 @@ type FieldLong func(name string, fake FakeLong, properties ...FieldLongPropertie
 
 func newFieldLong() FieldLong {
 	return func(name string, fake FakeLong, properties ...FieldLongProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -393,9 +729,13 @@ func newFieldLong() FieldLong {
 			}
 		}
 
+		g = inspectToGenerate(g, r)
+
 		r["type"] = "long"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -439,7 +779,23 @@ func (l FieldLong) WithIndex(enabled bool) FieldLongProperties {
 	}
 }
 
-/* TODO: WithMeta(...) */
+// WithCopyTo() adds a "copy_to" property to a FieldLong, copying its value into one or more target fields.
+func (l FieldLong) WithCopyTo(targets ...string) FieldLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldLong.
+func (l FieldLong) WithMeta(value FieldMeta) FieldLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
 
 // WithNullValue() adds a "null_value" property to a long field.
 func (l FieldLong) WithNullValue(value int) FieldLongProperties {
@@ -450,9 +806,23 @@ func (l FieldLong) WithNullValue(value int) FieldLongProperties {
 	}
 }
 
-/* TODO: WithOnScriptError(...) */
+// WithOnScriptError() adds an "on_script_error" property to a FieldLong. It only applies together with WithScript().
+func (l FieldLong) WithOnScriptError(value FieldOnScriptError) FieldLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
 
-/* TODO: WithScript(...) */
+// WithScript() adds a "script" property to a FieldLong. Values of scripted fields are computed at index time, so they are never generated.
+func (l FieldLong) WithScript(value ScriptConfig) FieldLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
 
 // WithStore() adds a "store" property to a long field.
 func (l FieldLong) WithStore(enabled bool) FieldLongProperties {
@@ -463,7 +833,23 @@ func (l FieldLong) WithStore(enabled bool) FieldLongProperties {
 	}
 }
 
-/* TODO: WithTimeSeriesDimension(...) */
+// WithTimeSeriesDimension() adds a "time_series_dimension" property to a long field. Generated time series keep dimension values stable.
+func (l FieldLong) WithTimeSeriesDimension(enabled bool) FieldLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_dimension": enabled,
+		}
+	}
+}
+
+// WithTimeSeriesMetric() adds a "time_series_metric" property to a long field. Generated time series keep counters monotonically increasing.
+func (l FieldLong) WithTimeSeriesMetric(value FieldTimeSeriesMetric) FieldLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_metric": value,
+		}
+	}
+}
 
 // FLOAT
 
@@ -472,10 +858,10 @@ type FieldFloat func(name string, fake FakeFloats, properties ...FieldFloatPrope
 
 func newFieldFloat() FieldFloat {
 	return func(name string, fake FakeFloats, properties ...FieldFloatProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -485,9 +871,13 @@ func newFieldFloat() FieldFloat {
 			}
 		}
 
+		g = inspectToGenerate(g, r)
+
 		r["type"] = "float"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -531,7 +921,23 @@ func (f FieldFloat) WithIndex(enabled bool) FieldFloatProperties {
 	}
 }
 
-/* TODO: WithMeta(...) */
+// WithCopyTo() adds a "copy_to" property to a FieldFloat, copying its value into one or more target fields.
+func (f FieldFloat) WithCopyTo(targets ...string) FieldFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldFloat.
+func (f FieldFloat) WithMeta(value FieldMeta) FieldFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
 
 // WithNullValue() adds a "null_value" property to a float field.
 func (f FieldFloat) WithNullValue(value int) FieldFloatProperties {
@@ -542,9 +948,23 @@ func (f FieldFloat) WithNullValue(value int) FieldFloatProperties {
 	}
 }
 
-/* TODO: WithOnScriptError(...) */
+// WithOnScriptError() adds an "on_script_error" property to a FieldFloat. It only applies together with WithScript().
+func (f FieldFloat) WithOnScriptError(value FieldOnScriptError) FieldFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
 
-/* TODO: WithScript(...) */
+// WithScript() adds a "script" property to a FieldFloat. Values of scripted fields are computed at index time, so they are never generated.
+func (f FieldFloat) WithScript(value ScriptConfig) FieldFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
 
 // WithStore() adds a "store" property to a float field.
 func (f FieldFloat) WithStore(enabled bool) FieldFloatProperties {
@@ -555,19 +975,26 @@ func (f FieldFloat) WithStore(enabled bool) FieldFloatProperties {
 	}
 }
 
-/* TODO: WithTimeSeriesDimension(...) */
-
-// DOUBLE
+// WithTimeSeriesMetric() adds a "time_series_metric" property to a float field. Generated time series keep counters monotonically increasing.
+func (f FieldFloat) WithTimeSeriesMetric(value FieldTimeSeriesMetric) FieldFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_metric": value,
+		}
+	}
+}
+
+// DOUBLE
 
 type FieldDoubleProperties func() map[string]interface{}
 type FieldDouble func(name string, fake FakeDouble, properties ...FieldDoubleProperties) FieldFunc
 
 func newFieldDouble() FieldDouble {
 	return func(name string, fake FakeDouble, properties ...FieldDoubleProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -577,9 +1004,13 @@ func newFieldDouble() FieldDouble {
 			}
 		}
 
+		g = inspectToGenerate(g, r)
+
 		r["type"] = "double"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -623,7 +1054,23 @@ func (d FieldDouble) WithIndex(enabled bool) FieldDoubleProperties {
 	}
 }
 
-/* TODO: WithMeta(...) */
+// WithCopyTo() adds a "copy_to" property to a FieldDouble, copying its value into one or more target fields.
+func (d FieldDouble) WithCopyTo(targets ...string) FieldDoubleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldDouble.
+func (d FieldDouble) WithMeta(value FieldMeta) FieldDoubleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
 
 // WithNullValue() adds a "null_value" property to a double field.
 func (d FieldDouble) WithNullValue(value int) FieldDoubleProperties {
@@ -634,9 +1081,23 @@ func (d FieldDouble) WithNullValue(value int) FieldDoubleProperties {
 	}
 }
 
-/* TODO: WithOnScriptError(...) */
+// WithOnScriptError() adds an "on_script_error" property to a FieldDouble. It only applies together with WithScript().
+func (d FieldDouble) WithOnScriptError(value FieldOnScriptError) FieldDoubleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
 
-/* TODO: WithScript(...) */
+// WithScript() adds a "script" property to a FieldDouble. Values of scripted fields are computed at index time, so they are never generated.
+func (d FieldDouble) WithScript(value ScriptConfig) FieldDoubleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
 
 // WithStore() adds a "store" property to a double field.
 func (d FieldDouble) WithStore(enabled bool) FieldDoubleProperties {
@@ -647,7 +1108,14 @@ func (d FieldDouble) WithStore(enabled bool) FieldDoubleProperties {
 	}
 }
 
-/* TODO: WithTimeSeriesDimension(...) */
+// WithTimeSeriesMetric() adds a "time_series_metric" property to a double field. Generated time series keep counters monotonically increasing.
+func (d FieldDouble) WithTimeSeriesMetric(value FieldTimeSeriesMetric) FieldDoubleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_metric": value,
+		}
+	}
+}
 
 // SHORT
 
@@ -656,10 +1124,10 @@ type FieldShort func(name string, fake FakeShort, properties ...FieldShortProper
 
 func newFieldShort() FieldShort {
 	return func(name string, fake FakeShort, properties ...FieldShortProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -669,9 +1137,13 @@ func newFieldShort() FieldShort {
 			}
 		}
 
+		g = inspectToGenerate(g, r)
+
 		r["type"] = "short"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -715,7 +1187,23 @@ func (s FieldShort) WithIndex(enabled bool) FieldShortProperties {
 	}
 }
 
-/* TODO: WithMeta(...) */
+// WithCopyTo() adds a "copy_to" property to a FieldShort, copying its value into one or more target fields.
+func (s FieldShort) WithCopyTo(targets ...string) FieldShortProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldShort.
+func (s FieldShort) WithMeta(value FieldMeta) FieldShortProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
 
 // WithNullValue() adds a "null_value" property to a short field.
 func (s FieldShort) WithNullValue(value int) FieldShortProperties {
@@ -726,9 +1214,23 @@ func (s FieldShort) WithNullValue(value int) FieldShortProperties {
 	}
 }
 
-/* TODO: WithOnScriptError(...) */
+// WithOnScriptError() adds an "on_script_error" property to a FieldShort. It only applies together with WithScript().
+func (s FieldShort) WithOnScriptError(value FieldOnScriptError) FieldShortProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
 
-/* TODO: WithScript(...) */
+// WithScript() adds a "script" property to a FieldShort. Values of scripted fields are computed at index time, so they are never generated.
+func (s FieldShort) WithScript(value ScriptConfig) FieldShortProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
 
 // WithStore() adds a "store" property to a short field.
 func (s FieldShort) WithStore(enabled bool) FieldShortProperties {
@@ -739,7 +1241,23 @@ func (s FieldShort) WithStore(enabled bool) FieldShortProperties {
 	}
 }
 
-/* TODO: WithTimeSeriesDimension(...) */
+// WithTimeSeriesDimension() adds a "time_series_dimension" property to a short field. Generated time series keep dimension values stable.
+func (s FieldShort) WithTimeSeriesDimension(enabled bool) FieldShortProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_dimension": enabled,
+		}
+	}
+}
+
+// WithTimeSeriesMetric() adds a "time_series_metric" property to a short field. Generated time series keep counters monotonically increasing.
+func (s FieldShort) WithTimeSeriesMetric(value FieldTimeSeriesMetric) FieldShortProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_metric": value,
+		}
+	}
+}
 
 // BYTE
 
@@ -748,10 +1266,10 @@ type FieldByte func(name string, fake FakeByte, properties ...FieldBytePropertie
 
 func newFieldByte() FieldByte {
 	return func(name string, fake FakeByte, properties ...FieldByteProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -761,9 +1279,13 @@ func newFieldByte() FieldByte {
 			}
 		}
 
+		g = inspectToGenerate(g, r)
+
 		r["type"] = "byte"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -807,7 +1329,23 @@ func (b FieldByte) WithIndex(enabled bool) FieldByteProperties {
 	}
 }
 
-/* TODO: WithMeta(...) */
+// WithCopyTo() adds a "copy_to" property to a FieldByte, copying its value into one or more target fields.
+func (b FieldByte) WithCopyTo(targets ...string) FieldByteProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldByte.
+func (b FieldByte) WithMeta(value FieldMeta) FieldByteProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
 
 // WithNullValue() adds a "null_value" property to a byte field.
 func (b FieldByte) WithNullValue(value int) FieldByteProperties {
@@ -818,9 +1356,23 @@ func (b FieldByte) WithNullValue(value int) FieldByteProperties {
 	}
 }
 
-/* TODO: WithOnScriptError(...) */
+// WithOnScriptError() adds an "on_script_error" property to a FieldByte. It only applies together with WithScript().
+func (b FieldByte) WithOnScriptError(value FieldOnScriptError) FieldByteProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
 
-/* TODO: WithScript(...) */
+// WithScript() adds a "script" property to a FieldByte. Values of scripted fields are computed at index time, so they are never generated.
+func (b FieldByte) WithScript(value ScriptConfig) FieldByteProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
 
 // WithStore() adds a "store" property to a byte field.
 func (b FieldByte) WithStore(enabled bool) FieldByteProperties {
@@ -831,7 +1383,23 @@ func (b FieldByte) WithStore(enabled bool) FieldByteProperties {
 	}
 }
 
-/* TODO: WithTimeSeriesDimension(...) */
+// WithTimeSeriesDimension() adds a "time_series_dimension" property to a byte field. Generated time series keep dimension values stable.
+func (b FieldByte) WithTimeSeriesDimension(enabled bool) FieldByteProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_dimension": enabled,
+		}
+	}
+}
+
+// WithTimeSeriesMetric() adds a "time_series_metric" property to a byte field. Generated time series keep counters monotonically increasing.
+func (b FieldByte) WithTimeSeriesMetric(value FieldTimeSeriesMetric) FieldByteProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_metric": value,
+		}
+	}
+}
 
 // HALFFLOAT
 
@@ -840,10 +1408,10 @@ type FieldHalfFloat func(name string, fake FakeHalfFloat, properties ...FieldHal
 
 func newFieldHalfFloat() FieldHalfFloat {
 	return func(name string, fake FakeHalfFloat, properties ...FieldHalfFloatProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -853,9 +1421,13 @@ func newFieldHalfFloat() FieldHalfFloat {
 			}
 		}
 
+		g = inspectToGenerate(g, r)
+
 		r["type"] = "half_float"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -899,7 +1471,23 @@ func (h FieldHalfFloat) WithIndex(enabled bool) FieldHalfFloatProperties {
 	}
 }
 
-/* TODO: WithMeta(...) */
+// WithCopyTo() adds a "copy_to" property to a FieldHalfFloat, copying its value into one or more target fields.
+func (h FieldHalfFloat) WithCopyTo(targets ...string) FieldHalfFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldHalfFloat.
+func (h FieldHalfFloat) WithMeta(value FieldMeta) FieldHalfFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
 
 // WithNullValue() adds a "null_value" property to a half_float field.
 func (h FieldHalfFloat) WithNullValue(value int) FieldHalfFloatProperties {
@@ -910,9 +1498,23 @@ func (h FieldHalfFloat) WithNullValue(value int) FieldHalfFloatProperties {
 	}
 }
 
-/* TODO: WithOnScriptError(...) */
+// WithOnScriptError() adds an "on_script_error" property to a FieldHalfFloat. It only applies together with WithScript().
+func (h FieldHalfFloat) WithOnScriptError(value FieldOnScriptError) FieldHalfFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
 
-/* TODO: WithScript(...) */
+// WithScript() adds a "script" property to a FieldHalfFloat. Values of scripted fields are computed at index time, so they are never generated.
+func (h FieldHalfFloat) WithScript(value ScriptConfig) FieldHalfFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
 
 // WithStore() adds a "store" property to a half_float field.
 func (h FieldHalfFloat) WithStore(enabled bool) FieldHalfFloatProperties {
@@ -923,7 +1525,14 @@ func (h FieldHalfFloat) WithStore(enabled bool) FieldHalfFloatProperties {
 	}
 }
 
-/* TODO: WithTimeSeriesDimension(...) */
+// WithTimeSeriesMetric() adds a "time_series_metric" property to a half_float field. Generated time series keep counters monotonically increasing.
+func (h FieldHalfFloat) WithTimeSeriesMetric(value FieldTimeSeriesMetric) FieldHalfFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_metric": value,
+		}
+	}
+}
 
 // SCALEDFLOAT
 
@@ -932,10 +1541,10 @@ type FieldScaledFloat func(name string, fake FakeScaledFloat, properties ...Fiel
 
 func newFieldScaledFloat() FieldScaledFloat {
 	return func(name string, fake FakeScaledFloat, properties ...FieldScaledFloatProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -945,9 +1554,13 @@ func newFieldScaledFloat() FieldScaledFloat {
 			}
 		}
 
+		g = inspectToGenerate(g, r)
+
 		r["type"] = "scaled_float"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -991,7 +1604,23 @@ func (s FieldScaledFloat) WithIndex(enabled bool) FieldScaledFloatProperties {
 	}
 }
 
-/* TODO: WithMeta(...) */
+// WithCopyTo() adds a "copy_to" property to a FieldScaledFloat, copying its value into one or more target fields.
+func (s FieldScaledFloat) WithCopyTo(targets ...string) FieldScaledFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldScaledFloat.
+func (s FieldScaledFloat) WithMeta(value FieldMeta) FieldScaledFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
 
 // WithNullValue() adds a "null_value" property to a scaled_float field.
 func (s FieldScaledFloat) WithNullValue(value int) FieldScaledFloatProperties {
@@ -1002,9 +1631,23 @@ func (s FieldScaledFloat) WithNullValue(value int) FieldScaledFloatProperties {
 	}
 }
 
-/* TODO: WithOnScriptError(...) */
+// WithOnScriptError() adds an "on_script_error" property to a FieldScaledFloat. It only applies together with WithScript().
+func (s FieldScaledFloat) WithOnScriptError(value FieldOnScriptError) FieldScaledFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
 
-/* TODO: WithScript(...) */
+// WithScript() adds a "script" property to a FieldScaledFloat. Values of scripted fields are computed at index time, so they are never generated.
+func (s FieldScaledFloat) WithScript(value ScriptConfig) FieldScaledFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
 
 // WithStore() adds a "store" property to a scaled_float field.
 func (s FieldScaledFloat) WithStore(enabled bool) FieldScaledFloatProperties {
@@ -1015,7 +1658,14 @@ func (s FieldScaledFloat) WithStore(enabled bool) FieldScaledFloatProperties {
 	}
 }
 
-/* TODO: WithTimeSeriesDimension(...) */
+// WithTimeSeriesMetric() adds a "time_series_metric" property to a scaled_float field. Generated time series keep counters monotonically increasing.
+func (s FieldScaledFloat) WithTimeSeriesMetric(value FieldTimeSeriesMetric) FieldScaledFloatProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_metric": value,
+		}
+	}
+}
 
 // DATE
 
@@ -1024,10 +1674,10 @@ type FieldDate func(name string, fake FakeDates, properties ...FieldDateProperti
 
 func newFieldDate() FieldDate {
 	return func(name string, fake FakeDates, properties ...FieldDateProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -1037,9 +1687,13 @@ func newFieldDate() FieldDate {
 			}
 		}
 
+		g = inspectToGenerate(g, r)
+
 		r["type"] = "date"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -1087,9 +1741,23 @@ func (d FieldDate) WithIndex(enabled bool) FieldDateProperties {
 
 /* TODO: WithNullValue(...) */
 
-/* TODO: WithOnScriptError(...) */
+// WithOnScriptError() adds an "on_script_error" property to a FieldDate. It only applies together with WithScript().
+func (d FieldDate) WithOnScriptError(value FieldOnScriptError) FieldDateProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
 
-/* TODO: WithScript(...) */
+// WithScript() adds a "script" property to a FieldDate. Values of scripted fields are computed at index time, so they are never generated.
+func (d FieldDate) WithScript(value ScriptConfig) FieldDateProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
 
 // WithStore() adds a "store" property to a date field.
 func (d FieldDate) WithStore(enabled bool) FieldDateProperties {
@@ -1100,7 +1768,23 @@ func (d FieldDate) WithStore(enabled bool) FieldDateProperties {
 	}
 }
 
-/* TODO: WithMeta(...) */
+// WithCopyTo() adds a "copy_to" property to a FieldDate, copying its value into one or more target fields.
+func (d FieldDate) WithCopyTo(targets ...string) FieldDateProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldDate.
+func (d FieldDate) WithMeta(value FieldMeta) FieldDateProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
 
 // BOOLEAN
 
@@ -1109,10 +1793,10 @@ type FieldBoolean func(name string, fake FakeBoolean, properties ...FieldBoolean
 
 func newFieldBoolean() FieldBoolean {
 	return func(name string, fake FakeBoolean, properties ...FieldBooleanProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -1122,9 +1806,13 @@ func newFieldBoolean() FieldBoolean {
 			}
 		}
 
+		g = inspectToGenerate(g, r)
+
 		r["type"] = "boolean"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -1161,9 +1849,23 @@ func (b FieldBoolean) WithNullValue(value bool) FieldBooleanProperties {
 	}
 }
 
-/* TODO: WithOnScriptError(...) */
+// WithOnScriptError() adds an "on_script_error" property to a FieldBoolean. It only applies together with WithScript().
+func (b FieldBoolean) WithOnScriptError(value FieldOnScriptError) FieldBooleanProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
 
-/* TODO: WithScript(...) */
+// WithScript() adds a "script" property to a FieldBoolean. Values of scripted fields are computed at index time, so they are never generated.
+func (b FieldBoolean) WithScript(value ScriptConfig) FieldBooleanProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
 
 // WithStore() adds a "store" property to a boolean field.
 func (b FieldBoolean) WithStore(enabled bool) FieldBooleanProperties {
@@ -1174,9 +1876,32 @@ func (b FieldBoolean) WithStore(enabled bool) FieldBooleanProperties {
 	}
 }
 
-/* TODO: WithMeta(...) */
+// WithCopyTo() adds a "copy_to" property to a FieldBoolean, copying its value into one or more target fields.
+func (b FieldBoolean) WithCopyTo(targets ...string) FieldBooleanProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldBoolean.
+func (b FieldBoolean) WithMeta(value FieldMeta) FieldBooleanProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
 
-/* TODO: WithTimeSeriesDimension(...) */
+// WithTimeSeriesDimension() adds a "time_series_dimension" property to a boolean field. Generated time series keep dimension values stable.
+func (b FieldBoolean) WithTimeSeriesDimension(enabled bool) FieldBooleanProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_dimension": enabled,
+		}
+	}
+}
 
 // IP
 
@@ -1185,10 +1910,10 @@ type FieldIP func(name string, fake FakeIP, properties ...FieldIPProperties) Fie
 
 func newFieldIP() FieldIP {
 	return func(name string, fake FakeIP, properties ...FieldIPProperties) FieldFunc {
-		storeToGenerate(name, string(fake))
-
 		r := map[string]interface{}{}
 
+		g := generator{fake: fakeFuncs[string(fake)]}
+
 		for _, fn := range properties {
 			if fn == nil {
 				continue
@@ -1198,9 +1923,13 @@ func newFieldIP() FieldIP {
 			}
 		}
 
+		g = inspectToGenerate(g, r)
+
 		r["type"] = "ip"
 
 		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
 			return map[string]interface{}{
 				name: r,
 			}
@@ -1237,9 +1966,23 @@ func (i FieldIP) WithNullValue(value string) FieldIPProperties {
 	}
 }
 
-/* TODO: WithOnScriptError(...) */
+// WithOnScriptError() adds an "on_script_error" property to a FieldIP. It only applies together with WithScript().
+func (i FieldIP) WithOnScriptError(value FieldOnScriptError) FieldIPProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
 
-/* TODO: WithScript(...) */
+// WithScript() adds a "script" property to a FieldIP. Values of scripted fields are computed at index time, so they are never generated.
+func (i FieldIP) WithScript(value ScriptConfig) FieldIPProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
 
 // WithStore() adds a "store" property to an IP field.
 func (i FieldIP) WithStore(enabled bool) FieldIPProperties {
@@ -1250,4 +1993,2382 @@ func (i FieldIP) WithStore(enabled bool) FieldIPProperties {
 	}
 }
 
-/* TODO: WithTimeSeriesDimension(...) */
+// WithTimeSeriesDimension() adds a "time_series_dimension" property to an IP field. Generated time series keep dimension values stable.
+func (i FieldIP) WithTimeSeriesDimension(enabled bool) FieldIPProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_dimension": enabled,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldIP, copying its value into one or more target fields.
+func (i FieldIP) WithCopyTo(targets ...string) FieldIPProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldIP.
+func (i FieldIP) WithMeta(value FieldMeta) FieldIPProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// OBJECT
+
+// FieldDynamic defines how new, unmapped fields inside an object are handled.
+type FieldDynamic string
+
+var (
+	FieldDynamicTrue    FieldDynamic = "true"
+	FieldDynamicFalse   FieldDynamic = "false"
+	FieldDynamicStrict  FieldDynamic = "strict"
+	FieldDynamicRuntime FieldDynamic = "runtime"
+)
+
+type FieldObjectProperties func() map[string]interface{}
+type FieldObject func(name string, properties ...FieldObjectProperties) FieldFunc
+
+func newFieldObject() FieldObject {
+	return func(name string, properties ...FieldObjectProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "object"
+
+		g := generator{
+			children: claimChildren(r),
+		}
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithProperties() adds child fields to an object field. Children are generated inside the object instead of at the top level of a document.
+func (o FieldObject) WithProperties(fields ...FieldFunc) FieldObjectProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"properties": newChildren(fields),
+		}
+	}
+}
+
+// WithEnabled() adds an "enabled" property to an object field.
+func (o FieldObject) WithEnabled(enabled bool) FieldObjectProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"enabled": enabled,
+		}
+	}
+}
+
+// WithDynamic() adds a "dynamic" property to an object field.
+func (o FieldObject) WithDynamic(value FieldDynamic) FieldObjectProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"dynamic": value,
+		}
+	}
+}
+
+// NESTED
+
+type FieldNestedProperties func() map[string]interface{}
+type FieldNested func(name string, fake FakeCardinality, properties ...FieldNestedProperties) FieldFunc
+
+func newFieldNested() FieldNested {
+	return func(name string, fake FakeCardinality, properties ...FieldNestedProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "nested"
+
+		g := generator{
+			children:    claimChildren(r),
+			cardinality: &fake,
+		}
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithProperties() adds child fields to a nested field. Every generated nested object contains all of its children.
+func (n FieldNested) WithProperties(fields ...FieldFunc) FieldNestedProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"properties": newChildren(fields),
+		}
+	}
+}
+
+// WithDynamic() adds a "dynamic" property to a nested field.
+func (n FieldNested) WithDynamic(value FieldDynamic) FieldNestedProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"dynamic": value,
+		}
+	}
+}
+
+// WithIncludeInParent() adds an "include_in_parent" property to a nested field.
+func (n FieldNested) WithIncludeInParent(enabled bool) FieldNestedProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"include_in_parent": enabled,
+		}
+	}
+}
+
+// WithIncludeInRoot() adds an "include_in_root" property to a nested field.
+func (n FieldNested) WithIncludeInRoot(enabled bool) FieldNestedProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"include_in_root": enabled,
+		}
+	}
+}
+
+// GEOPOINT
+
+type FieldGeoPointProperties func() map[string]interface{}
+type FieldGeoPoint func(name string, fake FakeGeoPoint, properties ...FieldGeoPointProperties) FieldFunc
+
+func newFieldGeoPoint() FieldGeoPoint {
+	return func(name string, fake FakeGeoPoint, properties ...FieldGeoPointProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFunc(fake)}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "geo_point"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to a geo_point field.
+func (g FieldGeoPoint) WithDocValues(enabled bool) FieldGeoPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithIgnoreMalformed() adds an "ignore_malformed" property to a geo_point field.
+func (g FieldGeoPoint) WithIgnoreMalformed(enabled bool) FieldGeoPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_malformed": enabled,
+		}
+	}
+}
+
+// WithIgnoreZValue() adds an "ignore_z_value" property to a geo_point field.
+func (g FieldGeoPoint) WithIgnoreZValue(enabled bool) FieldGeoPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_z_value": enabled,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to a geo_point field.
+func (g FieldGeoPoint) WithIndex(enabled bool) FieldGeoPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithNullValue() adds a "null_value" property to a geo_point field, e.g. "41.12,-71.34" or "POINT (-71.34 41.12)".
+func (g FieldGeoPoint) WithNullValue(value string) FieldGeoPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"null_value": value,
+		}
+	}
+}
+
+// WithStore() adds a "store" property to a geo_point field.
+func (g FieldGeoPoint) WithStore(enabled bool) FieldGeoPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"store": enabled,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldGeoPoint, copying its value into one or more target fields.
+func (g FieldGeoPoint) WithCopyTo(targets ...string) FieldGeoPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldGeoPoint.
+func (g FieldGeoPoint) WithMeta(value FieldMeta) FieldGeoPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// GEOSHAPE
+
+// FieldGeoOrientation defines the vertex order used to parse polygons of shape fields.
+type FieldGeoOrientation string
+
+var (
+	FieldGeoOrientationRight FieldGeoOrientation = "right"
+	FieldGeoOrientationLeft  FieldGeoOrientation = "left"
+)
+
+type FieldGeoShapeProperties func() map[string]interface{}
+type FieldGeoShape func(name string, fake FakeGeoShape, properties ...FieldGeoShapeProperties) FieldFunc
+
+func newFieldGeoShape() FieldGeoShape {
+	return func(name string, fake FakeGeoShape, properties ...FieldGeoShapeProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFunc(fake)}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "geo_shape"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithCoerce() adds a "coerce" property to a geo_shape field.
+func (g FieldGeoShape) WithCoerce(enabled bool) FieldGeoShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"coerce": enabled,
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to a geo_shape field.
+func (g FieldGeoShape) WithDocValues(enabled bool) FieldGeoShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithIgnoreMalformed() adds an "ignore_malformed" property to a geo_shape field.
+func (g FieldGeoShape) WithIgnoreMalformed(enabled bool) FieldGeoShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_malformed": enabled,
+		}
+	}
+}
+
+// WithIgnoreZValue() adds an "ignore_z_value" property to a geo_shape field.
+func (g FieldGeoShape) WithIgnoreZValue(enabled bool) FieldGeoShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_z_value": enabled,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to a geo_shape field.
+func (g FieldGeoShape) WithIndex(enabled bool) FieldGeoShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithOrientation() adds an "orientation" property to a geo_shape field.
+func (g FieldGeoShape) WithOrientation(value FieldGeoOrientation) FieldGeoShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"orientation": value,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldGeoShape, copying its value into one or more target fields.
+func (g FieldGeoShape) WithCopyTo(targets ...string) FieldGeoShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldGeoShape.
+func (g FieldGeoShape) WithMeta(value FieldMeta) FieldGeoShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// POINT
+
+type FieldPointProperties func() map[string]interface{}
+type FieldPoint func(name string, fake FakeGeoPoint, properties ...FieldPointProperties) FieldFunc
+
+func newFieldPoint() FieldPoint {
+	return func(name string, fake FakeGeoPoint, properties ...FieldPointProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFunc(fake)}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "point"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithIgnoreMalformed() adds an "ignore_malformed" property to a point field.
+func (p FieldPoint) WithIgnoreMalformed(enabled bool) FieldPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_malformed": enabled,
+		}
+	}
+}
+
+// WithIgnoreZValue() adds an "ignore_z_value" property to a point field.
+func (p FieldPoint) WithIgnoreZValue(enabled bool) FieldPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_z_value": enabled,
+		}
+	}
+}
+
+// WithNullValue() adds a "null_value" property to a point field, e.g. "-71.34,41.12" or "POINT (-71.34 41.12)".
+func (p FieldPoint) WithNullValue(value string) FieldPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"null_value": value,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldPoint, copying its value into one or more target fields.
+func (p FieldPoint) WithCopyTo(targets ...string) FieldPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldPoint.
+func (p FieldPoint) WithMeta(value FieldMeta) FieldPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// SHAPE
+
+type FieldShapeProperties func() map[string]interface{}
+type FieldShape func(name string, fake FakeGeoShape, properties ...FieldShapeProperties) FieldFunc
+
+func newFieldShape() FieldShape {
+	return func(name string, fake FakeGeoShape, properties ...FieldShapeProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFunc(fake)}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "shape"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithCoerce() adds a "coerce" property to a shape field.
+func (s FieldShape) WithCoerce(enabled bool) FieldShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"coerce": enabled,
+		}
+	}
+}
+
+// WithIgnoreMalformed() adds an "ignore_malformed" property to a shape field.
+func (s FieldShape) WithIgnoreMalformed(enabled bool) FieldShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_malformed": enabled,
+		}
+	}
+}
+
+// WithIgnoreZValue() adds an "ignore_z_value" property to a shape field.
+func (s FieldShape) WithIgnoreZValue(enabled bool) FieldShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_z_value": enabled,
+		}
+	}
+}
+
+// WithOrientation() adds an "orientation" property to a shape field.
+func (s FieldShape) WithOrientation(value FieldGeoOrientation) FieldShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"orientation": value,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldShape, copying its value into one or more target fields.
+func (s FieldShape) WithCopyTo(targets ...string) FieldShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldShape.
+func (s FieldShape) WithMeta(value FieldMeta) FieldShapeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// DENSEVECTOR
+
+// FieldDenseVectorElementType defines the data type used to encode the values of a dense_vector field.
+type FieldDenseVectorElementType string
+
+var (
+	FieldDenseVectorElementTypeFloat FieldDenseVectorElementType = "float"
+	FieldDenseVectorElementTypeByte  FieldDenseVectorElementType = "byte"
+	FieldDenseVectorElementTypeBit   FieldDenseVectorElementType = "bit"
+)
+
+// FieldDenseVectorSimilarity defines the vector similarity metric used in kNN search.
+type FieldDenseVectorSimilarity string
+
+var (
+	FieldDenseVectorSimilarityL2Norm          FieldDenseVectorSimilarity = "l2_norm"
+	FieldDenseVectorSimilarityDotProduct      FieldDenseVectorSimilarity = "dot_product"
+	FieldDenseVectorSimilarityCosine          FieldDenseVectorSimilarity = "cosine"
+	FieldDenseVectorSimilarityMaxInnerProduct FieldDenseVectorSimilarity = "max_inner_product"
+)
+
+// FieldDenseVectorIndexType defines the kNN index algorithm and quantization of a dense_vector field.
+type FieldDenseVectorIndexType string
+
+var (
+	FieldDenseVectorIndexTypeHNSW     FieldDenseVectorIndexType = "hnsw"
+	FieldDenseVectorIndexTypeInt8HNSW FieldDenseVectorIndexType = "int8_hnsw"
+	FieldDenseVectorIndexTypeInt4HNSW FieldDenseVectorIndexType = "int4_hnsw"
+	FieldDenseVectorIndexTypeFlat     FieldDenseVectorIndexType = "flat"
+	FieldDenseVectorIndexTypeInt8Flat FieldDenseVectorIndexType = "int8_flat"
+	FieldDenseVectorIndexTypeInt4Flat FieldDenseVectorIndexType = "int4_flat"
+)
+
+// FieldDenseVectorIndexOptions defines the "index_options" of a dense_vector field. Zero values are omitted.
+type FieldDenseVectorIndexOptions struct {
+	Type               FieldDenseVectorIndexType `json:"type"`
+	M                  int                       `json:"m,omitempty"`
+	EFConstruction     int                       `json:"ef_construction,omitempty"`
+	ConfidenceInterval float64                   `json:"confidence_interval,omitempty"`
+}
+
+type FieldDenseVectorProperties func() map[string]interface{}
+type FieldDenseVector func(name string, fake FakeDenseVector, properties ...FieldDenseVectorProperties) FieldFunc
+
+func newFieldDenseVector() FieldDenseVector {
+	return func(name string, fake FakeDenseVector, properties ...FieldDenseVectorProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "dense_vector"
+
+		dims, _ := r["dims"].(int)
+		elementType, ok := r["element_type"].(FieldDenseVectorElementType)
+		if !ok {
+			elementType = FieldDenseVectorElementTypeFloat
+		}
+
+		g := generator{}
+		if fake != nil && dims > 0 {
+			g.fake = fake(dims, elementType)
+		}
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithDims() adds a "dims" property to a dense_vector field. It is also used to size generated vectors, so documents are only generated when it is set.
+func (d FieldDenseVector) WithDims(value int) FieldDenseVectorProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"dims": value,
+		}
+	}
+}
+
+// WithElementType() adds an "element_type" property to a dense_vector field.
+func (d FieldDenseVector) WithElementType(value FieldDenseVectorElementType) FieldDenseVectorProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"element_type": value,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to a dense_vector field.
+func (d FieldDenseVector) WithIndex(enabled bool) FieldDenseVectorProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithSimilarity() adds a "similarity" property to a dense_vector field.
+func (d FieldDenseVector) WithSimilarity(value FieldDenseVectorSimilarity) FieldDenseVectorProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"similarity": value,
+		}
+	}
+}
+
+// WithIndexOptions() adds an "index_options" property to a dense_vector field.
+func (d FieldDenseVector) WithIndexOptions(value FieldDenseVectorIndexOptions) FieldDenseVectorProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index_options": value,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldDenseVector.
+func (d FieldDenseVector) WithMeta(value FieldMeta) FieldDenseVectorProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// SPARSEVECTOR
+
+type FieldSparseVector func(name string, fake FakeSparseVector) FieldFunc
+
+func newFieldSparseVector() FieldSparseVector {
+	return func(name string, fake FakeSparseVector) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		r["type"] = "sparse_vector"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// RANKFEATURES
+
+type FieldRankFeaturesProperties func() map[string]interface{}
+type FieldRankFeatures func(name string, fake FakeSparseVector, properties ...FieldRankFeaturesProperties) FieldFunc
+
+func newFieldRankFeatures() FieldRankFeatures {
+	return func(name string, fake FakeSparseVector, properties ...FieldRankFeaturesProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "rank_features"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithPositiveScoreImpact() adds a "positive_score_impact" property to a rank_features field.
+func (r FieldRankFeatures) WithPositiveScoreImpact(enabled bool) FieldRankFeaturesProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"positive_score_impact": enabled,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldRankFeatures.
+func (r FieldRankFeatures) WithMeta(value FieldMeta) FieldRankFeaturesProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// COMPLETION
+
+// FieldCompletionContextType defines the kind of a completion context.
+type FieldCompletionContextType string
+
+var (
+	FieldCompletionContextTypeCategory FieldCompletionContextType = "category"
+	FieldCompletionContextTypeGeo      FieldCompletionContextType = "geo"
+)
+
+// FieldCompletionContext defines a context of a completion field. Contexts with a Path are read from another field of the document and are not generated.
+type FieldCompletionContext struct {
+	Name      string                     `json:"name"`
+	Type      FieldCompletionContextType `json:"type"`
+	Path      string                     `json:"path,omitempty"`
+	Precision int                        `json:"precision,omitempty"`
+}
+
+type FieldCompletionProperties func() map[string]interface{}
+type FieldCompletion func(name string, fake FakeCompletion, properties ...FieldCompletionProperties) FieldFunc
+
+func newFieldCompletion() FieldCompletion {
+	return func(name string, fake FakeCompletion, properties ...FieldCompletionProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "completion"
+
+		contexts, _ := r["contexts"].([]FieldCompletionContext)
+
+		g := generator{fake: fakeCompletion(fake, contexts)}
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithAnalyzer() adds an "analyzer" property to a completion field.
+func (c FieldCompletion) WithAnalyzer(value string) FieldCompletionProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"analyzer": value,
+		}
+	}
+}
+
+// WithSearchAnalyzer() adds a "search_analyzer" property to a completion field.
+func (c FieldCompletion) WithSearchAnalyzer(value string) FieldCompletionProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"search_analyzer": value,
+		}
+	}
+}
+
+// WithPreserveSeparators() adds a "preserve_separators" property to a completion field.
+func (c FieldCompletion) WithPreserveSeparators(enabled bool) FieldCompletionProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"preserve_separators": enabled,
+		}
+	}
+}
+
+// WithPreservePositionIncrements() adds a "preserve_position_increments" property to a completion field.
+func (c FieldCompletion) WithPreservePositionIncrements(enabled bool) FieldCompletionProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"preserve_position_increments": enabled,
+		}
+	}
+}
+
+// WithMaxInputLength() adds a "max_input_length" property to a completion field.
+func (c FieldCompletion) WithMaxInputLength(value int) FieldCompletionProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"max_input_length": value,
+		}
+	}
+}
+
+// WithContexts() adds a "contexts" property to a completion field. Generated suggestions carry a value for every context without a Path.
+func (c FieldCompletion) WithContexts(value []FieldCompletionContext) FieldCompletionProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"contexts": value,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldCompletion.
+func (c FieldCompletion) WithMeta(value FieldMeta) FieldCompletionProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// SEARCHASYOUTYPE
+
+type FieldSearchAsYouTypeProperties func() map[string]interface{}
+type FieldSearchAsYouType func(name string, fake Fake, properties ...FieldSearchAsYouTypeProperties) FieldFunc
+
+func newFieldSearchAsYouType() FieldSearchAsYouType {
+	return func(name string, fake Fake, properties ...FieldSearchAsYouTypeProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "search_as_you_type"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithAnalyzer() adds an "analyzer" property to a search_as_you_type field.
+func (s FieldSearchAsYouType) WithAnalyzer(value string) FieldSearchAsYouTypeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"analyzer": value,
+		}
+	}
+}
+
+// WithSearchAnalyzer() adds a "search_analyzer" property to a search_as_you_type field.
+func (s FieldSearchAsYouType) WithSearchAnalyzer(value string) FieldSearchAsYouTypeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"search_analyzer": value,
+		}
+	}
+}
+
+// WithMaxShingleSize() adds a "max_shingle_size" property to a search_as_you_type field. Elasticsearch accepts values from 2 to 4.
+func (s FieldSearchAsYouType) WithMaxShingleSize(value int) FieldSearchAsYouTypeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"max_shingle_size": value,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to a search_as_you_type field.
+func (s FieldSearchAsYouType) WithIndex(enabled bool) FieldSearchAsYouTypeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithNorms() adds a "norms" property to a search_as_you_type field.
+func (s FieldSearchAsYouType) WithNorms(enabled bool) FieldSearchAsYouTypeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"norms": enabled,
+		}
+	}
+}
+
+// WithStore() adds a "store" property to a search_as_you_type field.
+func (s FieldSearchAsYouType) WithStore(enabled bool) FieldSearchAsYouTypeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"store": enabled,
+		}
+	}
+}
+
+// WithTermVector() adds a "term_vector" property to a search_as_you_type field.
+func (s FieldSearchAsYouType) WithTermVector(value string) FieldSearchAsYouTypeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"term_vector": value,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldSearchAsYouType, copying its value into one or more target fields.
+func (s FieldSearchAsYouType) WithCopyTo(targets ...string) FieldSearchAsYouTypeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldSearchAsYouType.
+func (s FieldSearchAsYouType) WithMeta(value FieldMeta) FieldSearchAsYouTypeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// INTEGERRANGE
+
+type FieldIntegerRangeProperties func() map[string]interface{}
+type FieldIntegerRange func(name string, fake FakeIntegerRange, properties ...FieldIntegerRangeProperties) FieldFunc
+
+func newFieldIntegerRange() FieldIntegerRange {
+	return func(name string, fake FakeIntegerRange, properties ...FieldIntegerRangeProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "integer_range"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithCoerce() adds a "coerce" property to an integer_range field.
+func (i FieldIntegerRange) WithCoerce(enabled bool) FieldIntegerRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"coerce": enabled,
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to an integer_range field.
+func (i FieldIntegerRange) WithDocValues(enabled bool) FieldIntegerRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to an integer_range field.
+func (i FieldIntegerRange) WithIndex(enabled bool) FieldIntegerRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithStore() adds a "store" property to an integer_range field.
+func (i FieldIntegerRange) WithStore(enabled bool) FieldIntegerRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"store": enabled,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldIntegerRange, copying its value into one or more target fields.
+func (i FieldIntegerRange) WithCopyTo(targets ...string) FieldIntegerRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldIntegerRange.
+func (i FieldIntegerRange) WithMeta(value FieldMeta) FieldIntegerRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// LONGRANGE
+
+type FieldLongRangeProperties func() map[string]interface{}
+type FieldLongRange func(name string, fake FakeLongRange, properties ...FieldLongRangeProperties) FieldFunc
+
+func newFieldLongRange() FieldLongRange {
+	return func(name string, fake FakeLongRange, properties ...FieldLongRangeProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "long_range"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithCoerce() adds a "coerce" property to a long_range field.
+func (l FieldLongRange) WithCoerce(enabled bool) FieldLongRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"coerce": enabled,
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to a long_range field.
+func (l FieldLongRange) WithDocValues(enabled bool) FieldLongRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to a long_range field.
+func (l FieldLongRange) WithIndex(enabled bool) FieldLongRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithStore() adds a "store" property to a long_range field.
+func (l FieldLongRange) WithStore(enabled bool) FieldLongRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"store": enabled,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldLongRange, copying its value into one or more target fields.
+func (l FieldLongRange) WithCopyTo(targets ...string) FieldLongRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldLongRange.
+func (l FieldLongRange) WithMeta(value FieldMeta) FieldLongRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// FLOATRANGE
+
+type FieldFloatRangeProperties func() map[string]interface{}
+type FieldFloatRange func(name string, fake FakeFloatRange, properties ...FieldFloatRangeProperties) FieldFunc
+
+func newFieldFloatRange() FieldFloatRange {
+	return func(name string, fake FakeFloatRange, properties ...FieldFloatRangeProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "float_range"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithCoerce() adds a "coerce" property to a float_range field.
+func (f FieldFloatRange) WithCoerce(enabled bool) FieldFloatRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"coerce": enabled,
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to a float_range field.
+func (f FieldFloatRange) WithDocValues(enabled bool) FieldFloatRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to a float_range field.
+func (f FieldFloatRange) WithIndex(enabled bool) FieldFloatRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithStore() adds a "store" property to a float_range field.
+func (f FieldFloatRange) WithStore(enabled bool) FieldFloatRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"store": enabled,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldFloatRange, copying its value into one or more target fields.
+func (f FieldFloatRange) WithCopyTo(targets ...string) FieldFloatRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldFloatRange.
+func (f FieldFloatRange) WithMeta(value FieldMeta) FieldFloatRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// DOUBLERANGE
+
+type FieldDoubleRangeProperties func() map[string]interface{}
+type FieldDoubleRange func(name string, fake FakeDoubleRange, properties ...FieldDoubleRangeProperties) FieldFunc
+
+func newFieldDoubleRange() FieldDoubleRange {
+	return func(name string, fake FakeDoubleRange, properties ...FieldDoubleRangeProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "double_range"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithCoerce() adds a "coerce" property to a double_range field.
+func (d FieldDoubleRange) WithCoerce(enabled bool) FieldDoubleRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"coerce": enabled,
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to a double_range field.
+func (d FieldDoubleRange) WithDocValues(enabled bool) FieldDoubleRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to a double_range field.
+func (d FieldDoubleRange) WithIndex(enabled bool) FieldDoubleRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithStore() adds a "store" property to a double_range field.
+func (d FieldDoubleRange) WithStore(enabled bool) FieldDoubleRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"store": enabled,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldDoubleRange, copying its value into one or more target fields.
+func (d FieldDoubleRange) WithCopyTo(targets ...string) FieldDoubleRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldDoubleRange.
+func (d FieldDoubleRange) WithMeta(value FieldMeta) FieldDoubleRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// DATERANGE
+
+type FieldDateRangeProperties func() map[string]interface{}
+type FieldDateRange func(name string, fake FakeDateRange, properties ...FieldDateRangeProperties) FieldFunc
+
+func newFieldDateRange() FieldDateRange {
+	return func(name string, fake FakeDateRange, properties ...FieldDateRangeProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "date_range"
+
+		format, _ := r["format"].(string)
+
+		g := generator{fake: fakeDateRange(string(fake), format)}
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithCoerce() adds a "coerce" property to a date_range field.
+func (d FieldDateRange) WithCoerce(enabled bool) FieldDateRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"coerce": enabled,
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to a date_range field.
+func (d FieldDateRange) WithDocValues(enabled bool) FieldDateRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithFormat() adds a "format" property to a date_range field. Generated ranges are rendered in the first of the given formats.
+func (d FieldDateRange) WithFormat(value string) FieldDateRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"format": value,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to a date_range field.
+func (d FieldDateRange) WithIndex(enabled bool) FieldDateRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithStore() adds a "store" property to a date_range field.
+func (d FieldDateRange) WithStore(enabled bool) FieldDateRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"store": enabled,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldDateRange, copying its value into one or more target fields.
+func (d FieldDateRange) WithCopyTo(targets ...string) FieldDateRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldDateRange.
+func (d FieldDateRange) WithMeta(value FieldMeta) FieldDateRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// IPRANGE
+
+type FieldIPRangeProperties func() map[string]interface{}
+type FieldIPRange func(name string, fake FakeIPRange, properties ...FieldIPRangeProperties) FieldFunc
+
+func newFieldIPRange() FieldIPRange {
+	return func(name string, fake FakeIPRange, properties ...FieldIPRangeProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "ip_range"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to an ip_range field.
+func (i FieldIPRange) WithDocValues(enabled bool) FieldIPRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to an ip_range field.
+func (i FieldIPRange) WithIndex(enabled bool) FieldIPRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithStore() adds a "store" property to an ip_range field.
+func (i FieldIPRange) WithStore(enabled bool) FieldIPRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"store": enabled,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldIPRange, copying its value into one or more target fields.
+func (i FieldIPRange) WithCopyTo(targets ...string) FieldIPRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldIPRange.
+func (i FieldIPRange) WithMeta(value FieldMeta) FieldIPRangeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// WILDCARD
+
+type FieldWildcardProperties func() map[string]interface{}
+type FieldWildcard func(name string, fake FakeWildcard, properties ...FieldWildcardProperties) FieldFunc
+
+func newFieldWildcard() FieldWildcard {
+	return func(name string, fake FakeWildcard, properties ...FieldWildcardProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "wildcard"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithIgnoreAbove() adds an "ignore_above" property to a wildcard field.
+func (w FieldWildcard) WithIgnoreAbove(value int) FieldWildcardProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_above": value,
+		}
+	}
+}
+
+// WithNullValue() adds a "null_value" property to a wildcard field.
+func (w FieldWildcard) WithNullValue(value string) FieldWildcardProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"null_value": value,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldWildcard, copying its value into one or more target fields.
+func (w FieldWildcard) WithCopyTo(targets ...string) FieldWildcardProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldWildcard.
+func (w FieldWildcard) WithMeta(value FieldMeta) FieldWildcardProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// CONSTANTKEYWORD
+
+type FieldConstantKeyword func(name string, value string) FieldFunc
+
+func newFieldConstantKeyword() FieldConstantKeyword {
+	return func(name string, value string) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeConstant(value)}
+
+		r["type"] = "constant_keyword"
+		r["value"] = value
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// FLATTENED
+
+type FieldFlattenedProperties func() map[string]interface{}
+type FieldFlattened func(name string, fake FakeFlattened, properties ...FieldFlattenedProperties) FieldFunc
+
+func newFieldFlattened() FieldFlattened {
+	return func(name string, fake FakeFlattened, properties ...FieldFlattenedProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "flattened"
+
+		depthLimit, ok := r["depth_limit"].(int)
+		if !ok {
+			depthLimit = 20
+		}
+
+		g := generator{fake: fakeFlattened(string(fake), depthLimit)}
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithDepthLimit() adds a "depth_limit" property to a flattened field. Generated objects never exceed it.
+func (f FieldFlattened) WithDepthLimit(value int) FieldFlattenedProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"depth_limit": value,
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to a flattened field.
+func (f FieldFlattened) WithDocValues(enabled bool) FieldFlattenedProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithEagerGlobalOrdinals() adds an "eager_global_ordinals" property to a flattened field.
+func (f FieldFlattened) WithEagerGlobalOrdinals(enabled bool) FieldFlattenedProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"eager_global_ordinals": enabled,
+		}
+	}
+}
+
+// WithIgnoreAbove() adds an "ignore_above" property to a flattened field.
+func (f FieldFlattened) WithIgnoreAbove(value int) FieldFlattenedProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_above": value,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to a flattened field.
+func (f FieldFlattened) WithIndex(enabled bool) FieldFlattenedProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithNullValue() adds a "null_value" property to a flattened field.
+func (f FieldFlattened) WithNullValue(value string) FieldFlattenedProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"null_value": value,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldFlattened.
+func (f FieldFlattened) WithMeta(value FieldMeta) FieldFlattenedProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// MATCHONLYTEXT
+
+type FieldMatchOnlyTextProperties func() map[string]interface{}
+type FieldMatchOnlyText func(name string, fake Fake, properties ...FieldMatchOnlyTextProperties) FieldFunc
+
+func newFieldMatchOnlyText() FieldMatchOnlyText {
+	return func(name string, fake Fake, properties ...FieldMatchOnlyTextProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "match_only_text"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithFields() adds a "fields" property (multi-fields) to a match_only_text field. Sub-fields are indexed from the parent value, so they are never generated on their own.
+func (m FieldMatchOnlyText) WithFields(fields ...FieldFunc) FieldMatchOnlyTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"fields": newMultiFields(fields),
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldMatchOnlyText, copying its value into one or more target fields.
+func (m FieldMatchOnlyText) WithCopyTo(targets ...string) FieldMatchOnlyTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldMatchOnlyText.
+func (m FieldMatchOnlyText) WithMeta(value FieldMeta) FieldMatchOnlyTextProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// JOIN
+
+type FieldJoinProperties func() map[string]interface{}
+type FieldJoin func(name string, relations map[string][]string, properties ...FieldJoinProperties) FieldFunc
+
+func newFieldJoin() FieldJoin {
+	return func(name string, relations map[string][]string, properties ...FieldJoinProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{join: newGeneratorJoin(relations)}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "join"
+		r["relations"] = relations
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithEagerGlobalOrdinals() adds an "eager_global_ordinals" property to a join field.
+func (j FieldJoin) WithEagerGlobalOrdinals(enabled bool) FieldJoinProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"eager_global_ordinals": enabled,
+		}
+	}
+}
+
+// UNSIGNEDLONG
+
+type FieldUnsignedLongProperties func() map[string]interface{}
+type FieldUnsignedLong func(name string, fake FakeUnsignedLong, properties ...FieldUnsignedLongProperties) FieldFunc
+
+func newFieldUnsignedLong() FieldUnsignedLong {
+	return func(name string, fake FakeUnsignedLong, properties ...FieldUnsignedLongProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		g = inspectToGenerate(g, r)
+
+		r["type"] = "unsigned_long"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to an unsigned_long field.
+func (u FieldUnsignedLong) WithDocValues(enabled bool) FieldUnsignedLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithIgnoreMalformed() adds an "ignore_malformed" property to an unsigned_long field.
+func (u FieldUnsignedLong) WithIgnoreMalformed(enabled bool) FieldUnsignedLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_malformed": enabled,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to an unsigned_long field.
+func (u FieldUnsignedLong) WithIndex(enabled bool) FieldUnsignedLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithNullValue() adds a "null_value" property to an unsigned_long field.
+func (u FieldUnsignedLong) WithNullValue(value uint64) FieldUnsignedLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"null_value": value,
+		}
+	}
+}
+
+// WithStore() adds a "store" property to an unsigned_long field.
+func (u FieldUnsignedLong) WithStore(enabled bool) FieldUnsignedLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"store": enabled,
+		}
+	}
+}
+
+// WithTimeSeriesDimension() adds a "time_series_dimension" property to an unsigned_long field. Generated time series keep dimension values stable.
+func (u FieldUnsignedLong) WithTimeSeriesDimension(enabled bool) FieldUnsignedLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_dimension": enabled,
+		}
+	}
+}
+
+// WithTimeSeriesMetric() adds a "time_series_metric" property to an unsigned_long field. Generated time series keep counters monotonically increasing.
+func (u FieldUnsignedLong) WithTimeSeriesMetric(value FieldTimeSeriesMetric) FieldUnsignedLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"time_series_metric": value,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldUnsignedLong, copying its value into one or more target fields.
+func (u FieldUnsignedLong) WithCopyTo(targets ...string) FieldUnsignedLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldUnsignedLong.
+func (u FieldUnsignedLong) WithMeta(value FieldMeta) FieldUnsignedLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// DATENANOS
+
+type FieldDateNanosProperties func() map[string]interface{}
+type FieldDateNanos func(name string, fake FakeDateNanos, properties ...FieldDateNanosProperties) FieldFunc
+
+func newFieldDateNanos() FieldDateNanos {
+	return func(name string, fake FakeDateNanos, properties ...FieldDateNanosProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "date_nanos"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to a date_nanos field.
+func (d FieldDateNanos) WithDocValues(enabled bool) FieldDateNanosProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithFormat() adds a "format" property to a date_nanos field.
+func (d FieldDateNanos) WithFormat(value string) FieldDateNanosProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"format": value,
+		}
+	}
+}
+
+// WithIgnoreMalformed() adds an "ignore_malformed" property to a date_nanos field.
+func (d FieldDateNanos) WithIgnoreMalformed(enabled bool) FieldDateNanosProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_malformed": enabled,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to a date_nanos field.
+func (d FieldDateNanos) WithIndex(enabled bool) FieldDateNanosProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithNullValue() adds a "null_value" property to a date_nanos field.
+func (d FieldDateNanos) WithNullValue(value string) FieldDateNanosProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"null_value": value,
+		}
+	}
+}
+
+// WithStore() adds a "store" property to a date_nanos field.
+func (d FieldDateNanos) WithStore(enabled bool) FieldDateNanosProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"store": enabled,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldDateNanos, copying its value into one or more target fields.
+func (d FieldDateNanos) WithCopyTo(targets ...string) FieldDateNanosProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldDateNanos.
+func (d FieldDateNanos) WithMeta(value FieldMeta) FieldDateNanosProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// BINARY
+
+type FieldBinaryProperties func() map[string]interface{}
+type FieldBinary func(name string, fake FakeBinary, properties ...FieldBinaryProperties) FieldFunc
+
+func newFieldBinary() FieldBinary {
+	return func(name string, fake FakeBinary, properties ...FieldBinaryProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "binary"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to a binary field.
+func (b FieldBinary) WithDocValues(enabled bool) FieldBinaryProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithStore() adds a "store" property to a binary field.
+func (b FieldBinary) WithStore(enabled bool) FieldBinaryProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"store": enabled,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldBinary.
+func (b FieldBinary) WithMeta(value FieldMeta) FieldBinaryProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// VERSION
+
+type FieldVersion func(name string, fake FakeVersion) FieldFunc
+
+func newFieldVersion() FieldVersion {
+	return func(name string, fake FakeVersion) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		r["type"] = "version"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// TOKENCOUNT
+
+type FieldTokenCountProperties func() map[string]interface{}
+type FieldTokenCount func(name string, fake Fake, analyzer string, properties ...FieldTokenCountProperties) FieldFunc
+
+func newFieldTokenCount() FieldTokenCount {
+	return func(name string, fake Fake, analyzer string, properties ...FieldTokenCountProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "token_count"
+		r["analyzer"] = analyzer
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithDocValues() adds a "doc_values" property to a token_count field.
+func (t FieldTokenCount) WithDocValues(enabled bool) FieldTokenCountProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"doc_values": enabled,
+		}
+	}
+}
+
+// WithEnablePositionIncrements() adds an "enable_position_increments" property to a token_count field.
+func (t FieldTokenCount) WithEnablePositionIncrements(enabled bool) FieldTokenCountProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"enable_position_increments": enabled,
+		}
+	}
+}
+
+// WithIndex() adds an "index" property to a token_count field.
+func (t FieldTokenCount) WithIndex(enabled bool) FieldTokenCountProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"index": enabled,
+		}
+	}
+}
+
+// WithNullValue() adds a "null_value" property to a token_count field.
+func (t FieldTokenCount) WithNullValue(value int) FieldTokenCountProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"null_value": value,
+		}
+	}
+}
+
+// WithStore() adds a "store" property to a token_count field.
+func (t FieldTokenCount) WithStore(enabled bool) FieldTokenCountProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"store": enabled,
+		}
+	}
+}
+
+// WithCopyTo() adds a "copy_to" property to a FieldTokenCount, copying its value into one or more target fields.
+func (t FieldTokenCount) WithCopyTo(targets ...string) FieldTokenCountProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"copy_to": targets,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldTokenCount.
+func (t FieldTokenCount) WithMeta(value FieldMeta) FieldTokenCountProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// HISTOGRAM
+
+type FieldHistogramProperties func() map[string]interface{}
+type FieldHistogram func(name string, fake FakeHistogram, properties ...FieldHistogramProperties) FieldFunc
+
+func newFieldHistogram() FieldHistogram {
+	return func(name string, fake FakeHistogram, properties ...FieldHistogramProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "histogram"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithIgnoreMalformed() adds an "ignore_malformed" property to a histogram field.
+func (h FieldHistogram) WithIgnoreMalformed(enabled bool) FieldHistogramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_malformed": enabled,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldHistogram.
+func (h FieldHistogram) WithMeta(value FieldMeta) FieldHistogramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// AGGREGATEMETRICDOUBLE
+
+// FieldAggregateMetric defines a sub-metric stored by an aggregate_metric_double field.
+type FieldAggregateMetric string
+
+var (
+	FieldAggregateMetricMin        FieldAggregateMetric = "min"
+	FieldAggregateMetricMax        FieldAggregateMetric = "max"
+	FieldAggregateMetricSum        FieldAggregateMetric = "sum"
+	FieldAggregateMetricValueCount FieldAggregateMetric = "value_count"
+)
+
+type FieldAggregateMetricDoubleProperties func() map[string]interface{}
+type FieldAggregateMetricDouble func(name string, fake FakeAggregateMetricDouble, metrics []FieldAggregateMetric, defaultMetric FieldAggregateMetric, properties ...FieldAggregateMetricDoubleProperties) FieldFunc
+
+func newFieldAggregateMetricDouble() FieldAggregateMetricDouble {
+	return func(name string, fake FakeAggregateMetricDouble, metrics []FieldAggregateMetric, defaultMetric FieldAggregateMetric, properties ...FieldAggregateMetricDoubleProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeAggregateMetricDouble(string(fake), metrics)}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "aggregate_metric_double"
+		r["metrics"] = metrics
+		r["default_metric"] = defaultMetric
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldAggregateMetricDouble.
+func (a FieldAggregateMetricDouble) WithMeta(value FieldMeta) FieldAggregateMetricDoubleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// RANKFEATURE
+
+type FieldRankFeatureProperties func() map[string]interface{}
+type FieldRankFeature func(name string, fake FakeRankFeature, properties ...FieldRankFeatureProperties) FieldFunc
+
+func newFieldRankFeature() FieldRankFeature {
+	return func(name string, fake FakeRankFeature, properties ...FieldRankFeatureProperties) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFuncs[string(fake)]}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "rank_feature"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithPositiveScoreImpact() adds a "positive_score_impact" property to a rank_feature field.
+func (r FieldRankFeature) WithPositiveScoreImpact(enabled bool) FieldRankFeatureProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"positive_score_impact": enabled,
+		}
+	}
+}
+
+// WithMeta() adds a "meta" property to a FieldRankFeature.
+func (r FieldRankFeature) WithMeta(value FieldMeta) FieldRankFeatureProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"meta": value.properties(),
+		}
+	}
+}
+
+// PERCOLATOR
+
+type FieldPercolator func(name string, fake FakePercolator) FieldFunc
+
+func newFieldPercolator() FieldPercolator {
+	return func(name string, fake FakePercolator) FieldFunc {
+		r := map[string]interface{}{}
+
+		g := generator{fake: fakeFunc(fake)}
+
+		r["type"] = "percolator"
+
+		return func() map[string]interface{} {
+			storeToGenerate(r, g)
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// ALIAS
+
+type FieldAlias func(name string, path string) FieldFunc
+
+func newFieldAlias() FieldAlias {
+	return func(name string, path string) FieldFunc {
+		r := map[string]interface{}{}
+
+		r["type"] = "alias"
+		r["path"] = path
+
+		return func() map[string]interface{} {
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
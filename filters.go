@@ -0,0 +1,982 @@
+package porter
+
+/*
+
+This file contains the factory functions and types for constructing configurable token filters. Built-in
+filters (AnalyzerCustomFilter and NormalizerCustomFilter constants) always run with their defaults; the
+filters defined here carry their own parameters (e.g., WithSynonyms(), WithMaxShingleSize()) and are
+placed into AnalysisConfig.Filter.
+
+Custom analyzers and normalizers reference a configured filter by its name, the same way they reference
+a built-in one:
+
+	Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{porter.AnalyzerCustomFilterLowercase, "product_synonyms"})
+
+*/
+
+type filter struct {
+	ASCIIFolding       FilterASCIIFolding
+	EdgeNGram          FilterEdgeNGram
+	Elision            FilterElision
+	KeepWords          FilterKeepWords
+	KeywordMarker      FilterKeywordMarker
+	Length             FilterLength
+	NGram              FilterNGram
+	PatternReplace     FilterPatternReplace
+	Shingle            FilterShingle
+	Stemmer            FilterStemmer
+	Stop               FilterStop
+	Synonym            FilterSynonym
+	SynonymGraph       FilterSynonymGraph
+	WordDelimiterGraph FilterWordDelimiterGraph
+
+	ICUFolding    FilterICUFolding
+	ICUNormalizer FilterICUNormalizer
+	ICUCollation  FilterICUCollation
+	Phonetic      FilterPhonetic
+}
+
+// NewFilter() generates a map of token filter names and their corresponding definitions.
+func (a analysis) NewFilter(filters ...FilterFunc) map[string]interface{} {
+	r := map[string]interface{}{}
+
+	for _, fn := range filters {
+		if fn == nil {
+			continue
+		}
+		for k, v := range fn() {
+			r[k] = v
+		}
+	}
+
+	return r
+}
+
+type FilterFunc func() map[string]interface{}
+
+// FilterStemmerLanguage defines the stemming algorithms available to the stemmer filter.
+type FilterStemmerLanguage string
+
+var (
+	FilterStemmerArabic            FilterStemmerLanguage = "arabic"
+	FilterStemmerArmenian          FilterStemmerLanguage = "armenian"
+	FilterStemmerBasque            FilterStemmerLanguage = "basque"
+	FilterStemmerBengali           FilterStemmerLanguage = "bengali"
+	FilterStemmerBrazilian         FilterStemmerLanguage = "brazilian"
+	FilterStemmerBulgarian         FilterStemmerLanguage = "bulgarian"
+	FilterStemmerCatalan           FilterStemmerLanguage = "catalan"
+	FilterStemmerCzech             FilterStemmerLanguage = "czech"
+	FilterStemmerDanish            FilterStemmerLanguage = "danish"
+	FilterStemmerDutch             FilterStemmerLanguage = "dutch"
+	FilterStemmerEnglish           FilterStemmerLanguage = "english"
+	FilterStemmerLightEnglish      FilterStemmerLanguage = "light_english"
+	FilterStemmerMinimalEnglish    FilterStemmerLanguage = "minimal_english"
+	FilterStemmerPossessiveEnglish FilterStemmerLanguage = "possessive_english"
+	FilterStemmerPorter2           FilterStemmerLanguage = "porter2"
+	FilterStemmerLovins            FilterStemmerLanguage = "lovins"
+	FilterStemmerEstonian          FilterStemmerLanguage = "estonian"
+	FilterStemmerFinnish           FilterStemmerLanguage = "finnish"
+	FilterStemmerLightFinnish      FilterStemmerLanguage = "light_finnish"
+	FilterStemmerFrench            FilterStemmerLanguage = "french"
+	FilterStemmerLightFrench       FilterStemmerLanguage = "light_french"
+	FilterStemmerMinimalFrench     FilterStemmerLanguage = "minimal_french"
+	FilterStemmerGalician          FilterStemmerLanguage = "galician"
+	FilterStemmerMinimalGalician   FilterStemmerLanguage = "minimal_galician"
+	FilterStemmerGerman            FilterStemmerLanguage = "german"
+	FilterStemmerLightGerman       FilterStemmerLanguage = "light_german"
+	FilterStemmerMinimalGerman     FilterStemmerLanguage = "minimal_german"
+	FilterStemmerGreek             FilterStemmerLanguage = "greek"
+	FilterStemmerHindi             FilterStemmerLanguage = "hindi"
+	FilterStemmerHungarian         FilterStemmerLanguage = "hungarian"
+	FilterStemmerLightHungarian    FilterStemmerLanguage = "light_hungarian"
+	FilterStemmerIndonesian        FilterStemmerLanguage = "indonesian"
+	FilterStemmerIrish             FilterStemmerLanguage = "irish"
+	FilterStemmerItalian           FilterStemmerLanguage = "italian"
+	FilterStemmerLightItalian      FilterStemmerLanguage = "light_italian"
+	FilterStemmerLatvian           FilterStemmerLanguage = "latvian"
+	FilterStemmerLithuanian        FilterStemmerLanguage = "lithuanian"
+	FilterStemmerNorwegian         FilterStemmerLanguage = "norwegian"
+	FilterStemmerLightNorwegian    FilterStemmerLanguage = "light_norwegian"
+	FilterStemmerPortuguese        FilterStemmerLanguage = "portuguese"
+	FilterStemmerLightPortuguese   FilterStemmerLanguage = "light_portuguese"
+	FilterStemmerRomanian          FilterStemmerLanguage = "romanian"
+	FilterStemmerRussian           FilterStemmerLanguage = "russian"
+	FilterStemmerLightRussian      FilterStemmerLanguage = "light_russian"
+	FilterStemmerSerbian           FilterStemmerLanguage = "serbian"
+	FilterStemmerSorani            FilterStemmerLanguage = "sorani"
+	FilterStemmerSpanish           FilterStemmerLanguage = "spanish"
+	FilterStemmerLightSpanish      FilterStemmerLanguage = "light_spanish"
+	FilterStemmerSwedish           FilterStemmerLanguage = "swedish"
+	FilterStemmerLightSwedish      FilterStemmerLanguage = "light_swedish"
+	FilterStemmerTurkish           FilterStemmerLanguage = "turkish"
+)
+
+// ASCII FOLDING FILTER
+
+type FilterASCIIFoldingProperties func() map[string]interface{}
+type FilterASCIIFolding func(name string, properties ...FilterASCIIFoldingProperties) FilterFunc
+
+func newFilterASCIIFolding() FilterASCIIFolding {
+	return func(name string, properties ...FilterASCIIFoldingProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "asciifolding"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithPreserveOriginal() sets whether the original token is emitted next to the folded one.
+func (a FilterASCIIFolding) WithPreserveOriginal(enabled bool) FilterASCIIFoldingProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"preserve_original": enabled,
+		}
+	}
+}
+
+// EDGE N-GRAM FILTER
+
+type FilterEdgeNGramProperties func() map[string]interface{}
+type FilterEdgeNGram func(name string, properties ...FilterEdgeNGramProperties) FilterFunc
+
+func newFilterEdgeNGram() FilterEdgeNGram {
+	return func(name string, properties ...FilterEdgeNGramProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "edge_ngram"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithMinGram() sets the minimum length of characters in a gram.
+func (e FilterEdgeNGram) WithMinGram(value int) FilterEdgeNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"min_gram": value,
+		}
+	}
+}
+
+// WithMaxGram() sets the maximum length of characters in a gram.
+func (e FilterEdgeNGram) WithMaxGram(value int) FilterEdgeNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"max_gram": value,
+		}
+	}
+}
+
+// WithPreserveOriginal() sets whether the original token is emitted next to the grams.
+func (e FilterEdgeNGram) WithPreserveOriginal(enabled bool) FilterEdgeNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"preserve_original": enabled,
+		}
+	}
+}
+
+// ELISION FILTER
+
+type FilterElisionProperties func() map[string]interface{}
+type FilterElision func(name string, properties ...FilterElisionProperties) FilterFunc
+
+func newFilterElision() FilterElision {
+	return func(name string, properties ...FilterElisionProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "elision"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithArticles() sets the elided articles to remove (e.g., "l", "d").
+func (e FilterElision) WithArticles(value []string) FilterElisionProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"articles": value,
+		}
+	}
+}
+
+// WithArticlesPath() sets an external articles file path.
+func (e FilterElision) WithArticlesPath(value string) FilterElisionProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"articles_path": value,
+		}
+	}
+}
+
+// WithArticlesCase() sets whether articles are matched case-insensitively.
+func (e FilterElision) WithArticlesCase(enabled bool) FilterElisionProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"articles_case": enabled,
+		}
+	}
+}
+
+// KEEP WORDS FILTER
+
+type FilterKeepWordsProperties func() map[string]interface{}
+type FilterKeepWords func(name string, properties ...FilterKeepWordsProperties) FilterFunc
+
+func newFilterKeepWords() FilterKeepWords {
+	return func(name string, properties ...FilterKeepWordsProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "keep"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithKeepWords() sets the list of words to keep; all other tokens are removed.
+func (k FilterKeepWords) WithKeepWords(value []string) FilterKeepWordsProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"keep_words": value,
+		}
+	}
+}
+
+// WithKeepWordsPath() sets an external file path with the words to keep.
+func (k FilterKeepWords) WithKeepWordsPath(value string) FilterKeepWordsProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"keep_words_path": value,
+		}
+	}
+}
+
+// WithKeepWordsCase() sets whether the words are lowercased before matching.
+func (k FilterKeepWords) WithKeepWordsCase(enabled bool) FilterKeepWordsProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"keep_words_case": enabled,
+		}
+	}
+}
+
+// KEYWORD MARKER FILTER
+
+type FilterKeywordMarkerProperties func() map[string]interface{}
+type FilterKeywordMarker func(name string, properties ...FilterKeywordMarkerProperties) FilterFunc
+
+func newFilterKeywordMarker() FilterKeywordMarker {
+	return func(name string, properties ...FilterKeywordMarkerProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "keyword_marker"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithKeywords() sets the words that are marked as keywords and thus not stemmed.
+func (k FilterKeywordMarker) WithKeywords(value []string) FilterKeywordMarkerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"keywords": value,
+		}
+	}
+}
+
+// WithKeywordsPath() sets an external keywords file path.
+func (k FilterKeywordMarker) WithKeywordsPath(value string) FilterKeywordMarkerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"keywords_path": value,
+		}
+	}
+}
+
+// WithKeywordsPattern() sets a regular expression matching the keywords.
+func (k FilterKeywordMarker) WithKeywordsPattern(value string) FilterKeywordMarkerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"keywords_pattern": value,
+		}
+	}
+}
+
+// WithIgnoreCase() sets whether keywords are matched case-insensitively.
+func (k FilterKeywordMarker) WithIgnoreCase(enabled bool) FilterKeywordMarkerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_case": enabled,
+		}
+	}
+}
+
+// LENGTH FILTER
+
+type FilterLengthProperties func() map[string]interface{}
+type FilterLength func(name string, properties ...FilterLengthProperties) FilterFunc
+
+func newFilterLength() FilterLength {
+	return func(name string, properties ...FilterLengthProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "length"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithMin() sets the minimum token length.
+func (l FilterLength) WithMin(value int) FilterLengthProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"min": value,
+		}
+	}
+}
+
+// WithMax() sets the maximum token length.
+func (l FilterLength) WithMax(value int) FilterLengthProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"max": value,
+		}
+	}
+}
+
+// N-GRAM FILTER
+
+type FilterNGramProperties func() map[string]interface{}
+type FilterNGram func(name string, properties ...FilterNGramProperties) FilterFunc
+
+func newFilterNGram() FilterNGram {
+	return func(name string, properties ...FilterNGramProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "ngram"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithMinGram() sets the minimum length of characters in a gram.
+func (n FilterNGram) WithMinGram(value int) FilterNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"min_gram": value,
+		}
+	}
+}
+
+// WithMaxGram() sets the maximum length of characters in a gram.
+func (n FilterNGram) WithMaxGram(value int) FilterNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"max_gram": value,
+		}
+	}
+}
+
+// WithPreserveOriginal() sets whether the original token is emitted next to the grams.
+func (n FilterNGram) WithPreserveOriginal(enabled bool) FilterNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"preserve_original": enabled,
+		}
+	}
+}
+
+// PATTERN REPLACE FILTER
+
+type FilterPatternReplaceProperties func() map[string]interface{}
+type FilterPatternReplace func(name string, pattern string, properties ...FilterPatternReplaceProperties) FilterFunc
+
+func newFilterPatternReplace() FilterPatternReplace {
+	return func(name string, pattern string, properties ...FilterPatternReplaceProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "pattern_replace"
+			r["pattern"] = pattern
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithReplacement() sets the replacement string; capture groups are referenced as $1.
+func (p FilterPatternReplace) WithReplacement(value string) FilterPatternReplaceProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"replacement": value,
+		}
+	}
+}
+
+// WithAll() sets whether all matches are replaced, or only the first one.
+func (p FilterPatternReplace) WithAll(enabled bool) FilterPatternReplaceProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"all": enabled,
+		}
+	}
+}
+
+// SHINGLE FILTER
+
+type FilterShingleProperties func() map[string]interface{}
+type FilterShingle func(name string, properties ...FilterShingleProperties) FilterFunc
+
+func newFilterShingle() FilterShingle {
+	return func(name string, properties ...FilterShingleProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "shingle"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithMinShingleSize() sets the minimum number of tokens in a shingle.
+func (s FilterShingle) WithMinShingleSize(value int) FilterShingleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"min_shingle_size": value,
+		}
+	}
+}
+
+// WithMaxShingleSize() sets the maximum number of tokens in a shingle.
+func (s FilterShingle) WithMaxShingleSize(value int) FilterShingleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"max_shingle_size": value,
+		}
+	}
+}
+
+// WithOutputUnigrams() sets whether the original tokens are emitted next to the shingles.
+func (s FilterShingle) WithOutputUnigrams(enabled bool) FilterShingleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"output_unigrams": enabled,
+		}
+	}
+}
+
+// WithOutputUnigramsIfNoShingles() sets whether the original tokens are emitted when no shingles are produced.
+func (s FilterShingle) WithOutputUnigramsIfNoShingles(enabled bool) FilterShingleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"output_unigrams_if_no_shingles": enabled,
+		}
+	}
+}
+
+// WithTokenSeparator() sets the string used to join tokens into a shingle.
+func (s FilterShingle) WithTokenSeparator(value string) FilterShingleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"token_separator": value,
+		}
+	}
+}
+
+// WithFillerToken() sets the token used in place of removed stop words.
+func (s FilterShingle) WithFillerToken(value string) FilterShingleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"filler_token": value,
+		}
+	}
+}
+
+// STEMMER FILTER
+
+type FilterStemmer func(name string, language FilterStemmerLanguage) FilterFunc
+
+func newFilterStemmer() FilterStemmer {
+	return func(name string, language FilterStemmerLanguage) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			r["type"] = "stemmer"
+			r["language"] = language
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// STOP FILTER
+
+type FilterStopProperties func() map[string]interface{}
+type FilterStop func(name string, properties ...FilterStopProperties) FilterFunc
+
+func newFilterStop() FilterStop {
+	return func(name string, properties ...FilterStopProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "stop"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithStopwords() sets the stopwords to remove, or a predefined list such as "_english_".
+func (s FilterStop) WithStopwords(value []string) FilterStopProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"stopwords": value,
+		}
+	}
+}
+
+// WithStopwordsPath() sets an external stopwords file path.
+func (s FilterStop) WithStopwordsPath(value string) FilterStopProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"stopwords_path": value,
+		}
+	}
+}
+
+// WithIgnoreCase() sets whether stopwords are matched case-insensitively.
+func (s FilterStop) WithIgnoreCase(enabled bool) FilterStopProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"ignore_case": enabled,
+		}
+	}
+}
+
+// WithRemoveTrailing() sets whether a trailing stopword is removed (disable for completion suggesters).
+func (s FilterStop) WithRemoveTrailing(enabled bool) FilterStopProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"remove_trailing": enabled,
+		}
+	}
+}
+
+// SYNONYM FILTER
+
+type FilterSynonymProperties func() map[string]interface{}
+type FilterSynonym func(name string, properties ...FilterSynonymProperties) FilterFunc
+
+func newFilterSynonym() FilterSynonym {
+	return func(name string, properties ...FilterSynonymProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "synonym"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithSynonyms() sets inline synonym rules in Solr format (e.g., "tv, television").
+func (s FilterSynonym) WithSynonyms(value []string) FilterSynonymProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"synonyms": value,
+		}
+	}
+}
+
+// WithSynonymsPath() sets an external synonyms file path.
+func (s FilterSynonym) WithSynonymsPath(value string) FilterSynonymProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"synonyms_path": value,
+		}
+	}
+}
+
+// WithExpand() sets whether equivalent synonyms are expanded to all alternatives.
+func (s FilterSynonym) WithExpand(enabled bool) FilterSynonymProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"expand": enabled,
+		}
+	}
+}
+
+// WithLenient() sets whether invalid synonym rules are ignored instead of failing.
+func (s FilterSynonym) WithLenient(enabled bool) FilterSynonymProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"lenient": enabled,
+		}
+	}
+}
+
+// WithSynonymsSet() references a synonym set stored through the Synonyms API (built with NewSynonymsSet()).
+func (s FilterSynonym) WithSynonymsSet(value string) FilterSynonymProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"synonyms_set": value,
+		}
+	}
+}
+
+// WithUpdateable() sets whether the synonyms can be reloaded without reindexing. Updateable filters may only be used in search analyzers.
+func (s FilterSynonym) WithUpdateable(enabled bool) FilterSynonymProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"updateable": enabled,
+		}
+	}
+}
+
+// SYNONYM GRAPH FILTER
+
+type FilterSynonymGraphProperties func() map[string]interface{}
+type FilterSynonymGraph func(name string, properties ...FilterSynonymGraphProperties) FilterFunc
+
+func newFilterSynonymGraph() FilterSynonymGraph {
+	return func(name string, properties ...FilterSynonymGraphProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "synonym_graph"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithSynonyms() sets inline synonym rules in Solr format (e.g., "tv, television").
+func (s FilterSynonymGraph) WithSynonyms(value []string) FilterSynonymGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"synonyms": value,
+		}
+	}
+}
+
+// WithSynonymsPath() sets an external synonyms file path.
+func (s FilterSynonymGraph) WithSynonymsPath(value string) FilterSynonymGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"synonyms_path": value,
+		}
+	}
+}
+
+// WithExpand() sets whether equivalent synonyms are expanded to all alternatives.
+func (s FilterSynonymGraph) WithExpand(enabled bool) FilterSynonymGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"expand": enabled,
+		}
+	}
+}
+
+// WithLenient() sets whether invalid synonym rules are ignored instead of failing.
+func (s FilterSynonymGraph) WithLenient(enabled bool) FilterSynonymGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"lenient": enabled,
+		}
+	}
+}
+
+// WithSynonymsSet() references a synonym set stored through the Synonyms API (built with NewSynonymsSet()).
+func (s FilterSynonymGraph) WithSynonymsSet(value string) FilterSynonymGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"synonyms_set": value,
+		}
+	}
+}
+
+// WithUpdateable() sets whether the synonyms can be reloaded without reindexing. Updateable filters may only be used in search analyzers.
+func (s FilterSynonymGraph) WithUpdateable(enabled bool) FilterSynonymGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"updateable": enabled,
+		}
+	}
+}
+
+// WORD DELIMITER GRAPH FILTER
+
+type FilterWordDelimiterGraphProperties func() map[string]interface{}
+type FilterWordDelimiterGraph func(name string, properties ...FilterWordDelimiterGraphProperties) FilterFunc
+
+func newFilterWordDelimiterGraph() FilterWordDelimiterGraph {
+	return func(name string, properties ...FilterWordDelimiterGraphProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "word_delimiter_graph"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithAdjustOffsets() sets whether the offsets of split tokens are adjusted.
+func (w FilterWordDelimiterGraph) WithAdjustOffsets(enabled bool) FilterWordDelimiterGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"adjust_offsets": enabled,
+		}
+	}
+}
+
+// WithCatenateAll() sets whether all split parts are also emitted joined together.
+func (w FilterWordDelimiterGraph) WithCatenateAll(enabled bool) FilterWordDelimiterGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"catenate_all": enabled,
+		}
+	}
+}
+
+// WithCatenateNumbers() sets whether numeric parts are also emitted joined together.
+func (w FilterWordDelimiterGraph) WithCatenateNumbers(enabled bool) FilterWordDelimiterGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"catenate_numbers": enabled,
+		}
+	}
+}
+
+// WithCatenateWords() sets whether alphabetic parts are also emitted joined together.
+func (w FilterWordDelimiterGraph) WithCatenateWords(enabled bool) FilterWordDelimiterGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"catenate_words": enabled,
+		}
+	}
+}
+
+// WithGenerateNumberParts() sets whether numeric parts are emitted.
+func (w FilterWordDelimiterGraph) WithGenerateNumberParts(enabled bool) FilterWordDelimiterGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"generate_number_parts": enabled,
+		}
+	}
+}
+
+// WithGenerateWordParts() sets whether alphabetic parts are emitted.
+func (w FilterWordDelimiterGraph) WithGenerateWordParts(enabled bool) FilterWordDelimiterGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"generate_word_parts": enabled,
+		}
+	}
+}
+
+// WithPreserveOriginal() sets whether the original token is emitted next to its parts.
+func (w FilterWordDelimiterGraph) WithPreserveOriginal(enabled bool) FilterWordDelimiterGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"preserve_original": enabled,
+		}
+	}
+}
+
+// WithProtectedWords() sets the tokens that are never split.
+func (w FilterWordDelimiterGraph) WithProtectedWords(value []string) FilterWordDelimiterGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"protected_words": value,
+		}
+	}
+}
+
+// WithSplitOnCaseChange() sets whether tokens are split on letter case changes.
+func (w FilterWordDelimiterGraph) WithSplitOnCaseChange(enabled bool) FilterWordDelimiterGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"split_on_case_change": enabled,
+		}
+	}
+}
+
+// WithSplitOnNumerics() sets whether tokens are split on letter-number transitions.
+func (w FilterWordDelimiterGraph) WithSplitOnNumerics(enabled bool) FilterWordDelimiterGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"split_on_numerics": enabled,
+		}
+	}
+}
+
+// WithStemEnglishPossessive() sets whether a trailing 's is removed from each part.
+func (w FilterWordDelimiterGraph) WithStemEnglishPossessive(enabled bool) FilterWordDelimiterGraphProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"stem_english_possessive": enabled,
+		}
+	}
+}
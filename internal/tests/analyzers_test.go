@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
+	"github.com/xoticdsign/porter2/internal/tests/suite"
+)
+
+func TestAnalyze_Integration(t *testing.T) {
+	s, err := suite.New(t, false)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+
+	c := porter.Config{
+		Name: "porter_analyze",
+		Definition: porter.DefinitionConfig{
+			Settings: &porter.SettingsConfig{
+				Analysis: &porter.AnalysisConfig{
+					Tokenizer: a.NewTokenizer(
+						a.Tokenizer.EdgeNGram("autocomplete",
+							a.Tokenizer.EdgeNGram.WithMinGram(2),
+							a.Tokenizer.EdgeNGram.WithMaxGram(4),
+							a.Tokenizer.EdgeNGram.WithTokenChars([]porter.TokenizerTokenChars{porter.TokenizerTokenCharsLetter}),
+						),
+					),
+					Analyzer: a.NewAnalyzer(
+						a.Analyzer.Custom("lowercase_standard",
+							a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizerStandard),
+							a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{porter.AnalyzerCustomFilterLowercase}),
+						),
+						a.Analyzer.Custom("autocomplete",
+							a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizer("autocomplete")),
+							a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{porter.AnalyzerCustomFilterLowercase}),
+						),
+						a.Analyzer.Language("english_text", porter.AnalyzerLanguageEnglish),
+					),
+				},
+			},
+		},
+	}
+
+	err = s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndex(), s.Porter.Documents.NoDocuments())
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		analyzer string
+		text     string
+		expected []porter.Token
+	}{
+		{
+			name:     "custom analyzer case",
+			analyzer: "lowercase_standard",
+			text:     "Hello World",
+			expected: []porter.Token{
+				{Term: "hello", StartOffset: 0, EndOffset: 5, Type: "<ALPHANUM>", Position: 0},
+				{Term: "world", StartOffset: 6, EndOffset: 11, Type: "<ALPHANUM>", Position: 1},
+			},
+		},
+		{
+			name:     "configured tokenizer case",
+			analyzer: "autocomplete",
+			text:     "Quick",
+			expected: []porter.Token{
+				{Term: "qu", StartOffset: 0, EndOffset: 2, Type: "word", Position: 0},
+				{Term: "qui", StartOffset: 0, EndOffset: 3, Type: "word", Position: 1},
+				{Term: "quic", StartOffset: 0, EndOffset: 4, Type: "word", Position: 2},
+			},
+		},
+		{
+			name:     "language analyzer case",
+			analyzer: "english_text",
+			text:     "The running foxes",
+			expected: []porter.Token{
+				{Term: "run", StartOffset: 4, EndOffset: 11, Type: "<ALPHANUM>", Position: 1},
+				{Term: "fox", StartOffset: 12, EndOffset: 17, Type: "<ALPHANUM>", Position: 2},
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		s.T.Run(cs.name, func(t *testing.T) {
+			s.AssertTokens(t, c.Name, cs.analyzer, cs.text, cs.expected)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
+	"github.com/xoticdsign/porter2/internal/tests/suite"
+)
+
+func TestNewCharFilter_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	cf := s.Porter.Index.Settings.Analysis.CharFilter
+
+	cases := []struct {
+		name     string
+		in       porter.CharFilterFunc
+		expected map[string]interface{}
+	}{
+		{
+			name: "inline mapping case",
+			in:   cf.Mapping("german_ss", cf.Mapping.WithMappings([]string{"ß => ss", "ẞ => SS"})),
+			expected: map[string]interface{}{
+				"german_ss": map[string]interface{}{
+					"type":     "mapping",
+					"mappings": []string{"ß => ss", "ẞ => SS"},
+				},
+			},
+		},
+		{
+			name: "path mapping case",
+			in:   cf.Mapping("emoticons", cf.Mapping.WithMappingsPath("analysis/emoticons.txt")),
+			expected: map[string]interface{}{
+				"emoticons": map[string]interface{}{
+					"type":          "mapping",
+					"mappings_path": "analysis/emoticons.txt",
+				},
+			},
+		},
+		{
+			name: "pattern replace case",
+			in: cf.PatternReplace("sku_separators", `(\d+)-(?=\d)`,
+				cf.PatternReplace.WithReplacement("$1_"),
+				cf.PatternReplace.WithFlags([]porter.AnalyzerPatternFlags{porter.AnalyzerPatternFlagsCaseInsensitive}),
+			),
+			expected: map[string]interface{}{
+				"sku_separators": map[string]interface{}{
+					"type":        "pattern_replace",
+					"pattern":     `(\d+)-(?=\d)`,
+					"replacement": "$1_",
+					"flags":       "CASE_INSENSITIVE",
+				},
+			},
+		},
+		{
+			name: "html strip case",
+			in:   cf.HTMLStrip("strip_tags", cf.HTMLStrip.WithEscapedTags([]string{"b", "i"})),
+			expected: map[string]interface{}{
+				"strip_tags": map[string]interface{}{
+					"type":         "html_strip",
+					"escaped_tags": []string{"b", "i"},
+				},
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		s.T.Run(cs.name, func(t *testing.T) {
+			r := s.Porter.Index.Settings.Analysis.NewCharFilter(cs.in)
+
+			assert.Equal(t, cs.expected, r)
+		})
+	}
+}
+
+func TestCharFilterReferences_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+
+	analysis := &porter.AnalysisConfig{
+		CharFilter: a.NewCharFilter(
+			a.CharFilter.HTMLStrip("strip_tags"),
+			a.CharFilter.PatternReplace("sku_separators", `[-_/ ]`, a.CharFilter.PatternReplace.WithReplacement("")),
+		),
+		Analyzer: a.NewAnalyzer(a.Analyzer.Custom("descriptions",
+			a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizerStandard),
+			a.Analyzer.Custom.WithCharFilter([]porter.AnalyzerCustomCharFilter{"strip_tags"}),
+		)),
+		Normalizer: a.NewNormalizer(a.Normalizer.Custom("sku",
+			a.Normalizer.Custom.WithCharFilter([]porter.NormalizerCustomCharFilter{"sku_separators"}),
+		)),
+	}
+
+	c := porter.Config{
+		Name: "porter_char_filters",
+		Definition: porter.DefinitionConfig{
+			Settings: &porter.SettingsConfig{
+				Analysis: analysis,
+			},
+		},
+	}
+
+	err = s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndex(), s.Porter.Documents.NoDocuments())
+	assert.NoError(t, err)
+
+	analysis.CharFilter = a.NewCharFilter(a.CharFilter.Mapping("strip_tags"))
+
+	err = s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndex(), s.Porter.Documents.NoDocuments())
+	assert.ErrorContains(t, err, "char_filter [strip_tags]: mapping requires either mappings or mappings_path")
+}
@@ -1,10 +1,17 @@
 package tests
 
 import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
 	"github.com/xoticdsign/porter2/internal/tests/suite"
 )
 
@@ -52,3 +59,435 @@ func TestLocationFromFile_Functional(t *testing.T) {
 		})
 	}
 }
+
+func TestFakeGeo_Functional(t *testing.T) {
+	box := porter.FakeBoundingBoxAround(porter.FakeGeoCityBerlin, 25)
+
+	t.Run("point around city case", func(t *testing.T) {
+		for c := 0; c < 100; c++ {
+			p, ok := porter.FakeGeoPointAround(porter.FakeGeoCityBerlin, 25)().([]float64)
+			assert.True(t, ok)
+			assert.Len(t, p, 2)
+
+			assert.GreaterOrEqual(t, p[0], box.MinLon)
+			assert.LessOrEqual(t, p[0], box.MaxLon)
+			assert.GreaterOrEqual(t, p[1], box.MinLat)
+			assert.LessOrEqual(t, p[1], box.MaxLat)
+		}
+	})
+
+	t.Run("polygon case", func(t *testing.T) {
+		for c := 0; c < 100; c++ {
+			shape, ok := porter.FakeGeoShapePolygon(box, 8)().(map[string]interface{})
+			assert.True(t, ok)
+			assert.Equal(t, "Polygon", shape["type"])
+
+			ring := shape["coordinates"].([][][]float64)[0]
+			assert.Len(t, ring, 9)
+			assert.Equal(t, ring[0], ring[len(ring)-1])
+
+			area := 0.0
+			for i := 0; i < len(ring)-1; i++ {
+				area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+
+				assert.GreaterOrEqual(t, ring[i][0], box.MinLon)
+				assert.LessOrEqual(t, ring[i][0], box.MaxLon)
+				assert.GreaterOrEqual(t, ring[i][1], box.MinLat)
+				assert.LessOrEqual(t, ring[i][1], box.MaxLat)
+			}
+			assert.Greater(t, area, 0.0, "ring must be counter-clockwise")
+
+			for i := 0; i < len(ring)-1; i++ {
+				for j := i + 2; j < len(ring)-1; j++ {
+					if i == 0 && j == len(ring)-2 {
+						continue
+					}
+					assert.False(t, segmentsIntersect(ring[i], ring[i+1], ring[j], ring[j+1]), "ring must not self-intersect")
+				}
+			}
+		}
+	})
+
+	t.Run("linestring case", func(t *testing.T) {
+		shape, ok := porter.FakeGeoShapeLineString(box, 5)().(map[string]interface{})
+		assert.True(t, ok)
+		assert.Equal(t, "LineString", shape["type"])
+
+		line := shape["coordinates"].([][]float64)
+		assert.Len(t, line, 5)
+
+		for i := 1; i < len(line); i++ {
+			assert.Greater(t, line[i][0], line[i-1][0])
+		}
+	})
+}
+
+func segmentsIntersect(a, b, c, d []float64) bool {
+	cross := func(o, p, q []float64) float64 {
+		return (p[0]-o[0])*(q[1]-o[1]) - (p[1]-o[1])*(q[0]-o[0])
+	}
+
+	d1 := cross(c, d, a)
+	d2 := cross(c, d, b)
+	d3 := cross(a, b, c)
+	d4 := cross(a, b, d)
+
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
+func TestFakeDenseVector_Functional(t *testing.T) {
+	norm := func(v []float64) float64 {
+		n := 0.0
+		for _, x := range v {
+			n += x * x
+		}
+		return math.Sqrt(n)
+	}
+
+	t.Run("normalized case", func(t *testing.T) {
+		fc := porter.FakeDenseVectorNormalized()(16, porter.FieldDenseVectorElementTypeFloat)
+
+		for c := 0; c < 50; c++ {
+			v, ok := fc().([]float64)
+			assert.True(t, ok)
+			assert.Len(t, v, 16)
+			assert.InDelta(t, 1.0, norm(v), 1e-4)
+		}
+	})
+
+	t.Run("clustered case", func(t *testing.T) {
+		fc := porter.FakeDenseVectorClustered(3, 0.001)(32, porter.FieldDenseVectorElementTypeFloat)
+
+		centroids := [][]float64{}
+
+		for c := 0; c < 200; c++ {
+			v := fc().([]float64)
+			assert.Len(t, v, 32)
+
+			found := false
+			for _, cn := range centroids {
+				dot := 0.0
+				for i := range v {
+					dot += v[i] * cn[i]
+				}
+				if dot > 0.99 {
+					found = true
+					break
+				}
+			}
+			if !found {
+				centroids = append(centroids, v)
+			}
+		}
+
+		assert.LessOrEqual(t, len(centroids), 3)
+	})
+
+	t.Run("byte element type case", func(t *testing.T) {
+		v, ok := porter.FakeDenseVectorNormalized()(8, porter.FieldDenseVectorElementTypeByte)().([]int)
+		assert.True(t, ok)
+		assert.Len(t, v, 8)
+
+		for _, x := range v {
+			assert.GreaterOrEqual(t, x, -128)
+			assert.LessOrEqual(t, x, 127)
+		}
+	})
+}
+
+func TestFakeRange_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	s.Porter.Index.Mappings.NewFields(
+		p.IntegerRange("range_integer", porter.FakeIntegerRangeInt),
+		p.DoubleRange("range_double", porter.FakeDoubleRangeFloat),
+		p.DateRange("range_date", porter.FakeDateRangeDate, p.DateRange.WithFormat("yyyy-MM-dd HH:mm:ss||epoch_millis")),
+		p.DateRange("range_date_epoch", porter.FakeDateRangeDate, p.DateRange.WithFormat("epoch_millis")),
+		p.IPRange("range_ipv4", porter.FakeIPRangeIPv4),
+		p.IPRange("range_ipv6", porter.FakeIPRangeIPv6),
+	)
+
+	for c := 0; c < 20; c++ {
+		docs, err := s.Porter.Documents.Origin.Generate(1)(s.Temp)
+		assert.NoError(t, err)
+
+		lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+		assert.Len(t, lines, 2)
+
+		d := json.NewDecoder(bytes.NewReader(lines[1]))
+		d.UseNumber()
+
+		var raw map[string]interface{}
+		assert.NoError(t, d.Decode(&raw))
+
+		doc := map[string]map[string]interface{}{}
+		for _, k := range []string{"range_integer", "range_double", "range_date", "range_date_epoch"} {
+			m, ok := raw[k].(map[string]interface{})
+			assert.True(t, ok, k)
+			doc[k] = m
+		}
+
+		for _, k := range []string{"range_integer", "range_double", "range_date_epoch"} {
+			gte, _ := doc[k]["gte"].(json.Number).Float64()
+			lte, _ := doc[k]["lte"].(json.Number).Float64()
+			assert.LessOrEqual(t, gte, lte, k)
+		}
+
+		gte, err := time.Parse("2006-01-02 15:04:05", doc["range_date"]["gte"].(string))
+		assert.NoError(t, err)
+		lte, err := time.Parse("2006-01-02 15:04:05", doc["range_date"]["lte"].(string))
+		assert.NoError(t, err)
+		assert.False(t, lte.Before(gte))
+
+		for _, k := range []string{"range_ipv4", "range_ipv6"} {
+			cidr, ok := raw[k].(string)
+			assert.True(t, ok, k)
+
+			ip, network, err := net.ParseCIDR(cidr)
+			assert.NoError(t, err, cidr)
+			assert.True(t, ip.Equal(network.IP), cidr)
+		}
+	}
+}
+
+func TestFakeDateNanos_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	s.Porter.Index.Mappings.NewFields(
+		p.DateNanos("nanos_timestamp", porter.FakeDateNanosTimestamp),
+	)
+
+	docs, err := s.Porter.Documents.Origin.Generate(200)(s.Temp)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+	assert.Len(t, lines, 400)
+
+	for i := 1; i < len(lines); i += 2 {
+		var doc map[string]interface{}
+		assert.NoError(t, json.Unmarshal(lines[i], &doc))
+
+		v, ok := doc["nanos_timestamp"].(string)
+		assert.True(t, ok)
+
+		ts, err := time.Parse(time.RFC3339Nano, v)
+		assert.NoError(t, err, v)
+		assert.False(t, ts.Before(time.Unix(0, 0)), v)
+	}
+}
+
+func TestLocationGenerateJoin_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	temp := s.Temp
+	temp.Config.Definition.Mappings = &porter.MappingsConfig{
+		Properties: s.Porter.Index.Mappings.NewFields(
+			p.Join("qa_join", map[string][]string{"question": {"answer"}}),
+			p.Text("qa_body", porter.FakeParagraph),
+		),
+	}
+
+	docs, err := s.Porter.Documents.Origin.GenerateJoin(2, 3)(temp)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+	assert.Len(t, lines, 16)
+
+	parents := map[string]bool{}
+	children := 0
+
+	for c := 0; c < len(lines); c += 2 {
+		var meta map[string]map[string]interface{}
+		var doc map[string]interface{}
+
+		assert.NoError(t, json.Unmarshal(lines[c], &meta))
+		assert.NoError(t, json.Unmarshal(lines[c+1], &doc))
+
+		switch join := doc["qa_join"].(type) {
+		case string:
+			assert.Equal(t, "question", join)
+			assert.NotContains(t, meta["index"], "routing")
+
+			parents[meta["index"]["_id"].(string)] = true
+
+		case map[string]interface{}:
+			assert.Equal(t, "answer", join["name"])
+			assert.Equal(t, join["parent"], meta["index"]["routing"])
+			assert.True(t, parents[join["parent"].(string)], "child must follow its parent")
+
+			children++
+
+		default:
+			assert.Fail(t, "unexpected join value")
+		}
+	}
+
+	assert.Len(t, parents, 2)
+	assert.Equal(t, 6, children)
+}
+
+func TestLocationGenerateJoinRelations_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	temp := s.Temp
+	temp.Config.Definition.Mappings = &porter.MappingsConfig{
+		Properties: s.Porter.Index.Mappings.NewFields(
+			p.Join("forum_join", map[string][]string{
+				"question": {"answer", "comment"},
+				"answer":   {"vote"},
+				"product":  {"review"},
+			}),
+		),
+	}
+
+	docs, err := s.Porter.Documents.Origin.GenerateJoin(1, 2)(temp)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+
+	relations := map[string]int{}
+	routings := map[string]string{}
+
+	for c := 0; c < len(lines); c += 2 {
+		var meta map[string]map[string]interface{}
+		var doc map[string]interface{}
+
+		assert.NoError(t, json.Unmarshal(lines[c], &meta))
+		assert.NoError(t, json.Unmarshal(lines[c+1], &doc))
+
+		id := meta["index"]["_id"].(string)
+
+		switch join := doc["forum_join"].(type) {
+		case string:
+			relations[join]++
+			routings[id] = id
+
+		case map[string]interface{}:
+			relations[join["name"].(string)]++
+
+			routing, ok := routings[join["parent"].(string)]
+			assert.True(t, ok, "child must follow its parent")
+			assert.Equal(t, routing, meta["index"]["routing"], "children must be routed to the top-level parent")
+
+			routings[id] = routing
+
+		default:
+			assert.Fail(t, "unexpected join value")
+		}
+	}
+
+	assert.Equal(t, map[string]int{
+		"question": 1,
+		"answer":   2,
+		"comment":  2,
+		"vote":     4,
+		"product":  1,
+		"review":   2,
+	}, relations)
+}
+
+func TestLocationGenerateTimeSeries_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	s.Porter.Index.Mappings.NewFields(
+		p.Keyword("tsdb_host", porter.FakeUUID, p.Keyword.WithTimeSeriesDimension(true)),
+		p.Long("tsdb_requests", porter.FakeLongInt, p.Long.WithTimeSeriesMetric(porter.FieldTimeSeriesMetricCounter)),
+		p.Double("tsdb_cpu", porter.FakeDoubleFloat, p.Double.WithTimeSeriesMetric(porter.FieldTimeSeriesMetricGauge)),
+	)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := porter.FakeTimeWindow{
+		Start:    start,
+		End:      start.Add(5 * time.Minute),
+		Interval: time.Minute,
+	}
+
+	docs, err := s.Porter.Documents.Origin.GenerateTimeSeries(3, window)(s.Temp)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+	assert.Len(t, lines, 30)
+
+	hosts := map[string]bool{}
+	last := map[string]float64{}
+
+	for c := 0; c < len(lines); c += 2 {
+		var meta map[string]map[string]interface{}
+		var doc map[string]interface{}
+
+		assert.NoError(t, json.Unmarshal(lines[c], &meta))
+		assert.NoError(t, json.Unmarshal(lines[c+1], &doc))
+
+		assert.NotContains(t, meta["create"], "_id")
+
+		ts, err := time.Parse(time.RFC3339, doc["@timestamp"].(string))
+		assert.NoError(t, err)
+		assert.False(t, ts.Before(window.Start))
+		assert.True(t, ts.Before(window.End))
+
+		host := doc["tsdb_host"].(string)
+		requests := doc["tsdb_requests"].(float64)
+
+		if previous, ok := last[host]; ok {
+			assert.GreaterOrEqual(t, requests, previous, "counters must not decrease within a series")
+		}
+
+		hosts[host] = true
+		last[host] = requests
+	}
+
+	assert.Len(t, hosts, 3)
+
+	_, err = s.Porter.Documents.Origin.GenerateTimeSeries(1, porter.FakeTimeWindow{Start: start, End: start})(s.Temp)
+	assert.ErrorIs(t, err, porter.ErrOriginTimeWindow)
+
+	s.Porter.Index.Mappings.NewFields(
+		p.Keyword("tsdb_host", porter.FakeBool, p.Keyword.WithTimeSeriesDimension(true)),
+	)
+
+	_, err = s.Porter.Documents.Origin.GenerateTimeSeries(3, window)(s.Temp)
+	assert.ErrorIs(t, err, porter.ErrOriginTimeSeries)
+}
+
+func TestBooleanTimeSeriesDimension_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	expected := map[string]interface{}{
+		"tsdb_enabled": map[string]interface{}{
+			"type":                  "boolean",
+			"time_series_dimension": true,
+		},
+	}
+
+	assert.Equal(t, expected, p.Boolean("tsdb_enabled", porter.FakeBooleanBool, p.Boolean.WithTimeSeriesDimension(true))())
+}
@@ -0,0 +1,371 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
+	"github.com/xoticdsign/porter2/internal/tests/suite"
+)
+
+func TestEmulateBuiltin_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	c := porter.Config{Name: "porter_emulate"}
+
+	cases := []struct {
+		name     string
+		analyzer string
+		text     string
+		expected []porter.Token
+	}{
+		{
+			name:     "standard case",
+			analyzer: "standard",
+			text:     "The 2 QUICK Brown-Foxes jumped over the lazy dog's bone.",
+			expected: []porter.Token{
+				{Term: "the", StartOffset: 0, EndOffset: 3, Type: "<ALPHANUM>", Position: 0},
+				{Term: "2", StartOffset: 4, EndOffset: 5, Type: "<NUM>", Position: 1},
+				{Term: "quick", StartOffset: 6, EndOffset: 11, Type: "<ALPHANUM>", Position: 2},
+				{Term: "brown", StartOffset: 12, EndOffset: 17, Type: "<ALPHANUM>", Position: 3},
+				{Term: "foxes", StartOffset: 18, EndOffset: 23, Type: "<ALPHANUM>", Position: 4},
+				{Term: "jumped", StartOffset: 24, EndOffset: 30, Type: "<ALPHANUM>", Position: 5},
+				{Term: "over", StartOffset: 31, EndOffset: 35, Type: "<ALPHANUM>", Position: 6},
+				{Term: "the", StartOffset: 36, EndOffset: 39, Type: "<ALPHANUM>", Position: 7},
+				{Term: "lazy", StartOffset: 40, EndOffset: 44, Type: "<ALPHANUM>", Position: 8},
+				{Term: "dog's", StartOffset: 45, EndOffset: 50, Type: "<ALPHANUM>", Position: 9},
+				{Term: "bone", StartOffset: 51, EndOffset: 55, Type: "<ALPHANUM>", Position: 10},
+			},
+		},
+		{
+			name:     "standard numbers and cjk case",
+			analyzer: "standard",
+			text:     "v1.2 costs 1,000.50 in 東京",
+			expected: []porter.Token{
+				{Term: "v1.2", StartOffset: 0, EndOffset: 4, Type: "<ALPHANUM>", Position: 0},
+				{Term: "costs", StartOffset: 5, EndOffset: 10, Type: "<ALPHANUM>", Position: 1},
+				{Term: "1,000.50", StartOffset: 11, EndOffset: 19, Type: "<NUM>", Position: 2},
+				{Term: "in", StartOffset: 20, EndOffset: 22, Type: "<ALPHANUM>", Position: 3},
+				{Term: "東", StartOffset: 23, EndOffset: 24, Type: "<IDEOGRAPHIC>", Position: 4},
+				{Term: "京", StartOffset: 24, EndOffset: 25, Type: "<IDEOGRAPHIC>", Position: 5},
+			},
+		},
+		{
+			name:     "simple case",
+			analyzer: "simple",
+			text:     "The 2 QUICK dog's",
+			expected: []porter.Token{
+				{Term: "the", StartOffset: 0, EndOffset: 3, Type: "word", Position: 0},
+				{Term: "quick", StartOffset: 6, EndOffset: 11, Type: "word", Position: 1},
+				{Term: "dog", StartOffset: 12, EndOffset: 15, Type: "word", Position: 2},
+				{Term: "s", StartOffset: 16, EndOffset: 17, Type: "word", Position: 3},
+			},
+		},
+		{
+			name:     "whitespace case",
+			analyzer: "whitespace",
+			text:     "The  QUICK-brown fox.",
+			expected: []porter.Token{
+				{Term: "The", StartOffset: 0, EndOffset: 3, Type: "word", Position: 0},
+				{Term: "QUICK-brown", StartOffset: 5, EndOffset: 16, Type: "word", Position: 1},
+				{Term: "fox.", StartOffset: 17, EndOffset: 21, Type: "word", Position: 2},
+			},
+		},
+		{
+			name:     "keyword case",
+			analyzer: "keyword",
+			text:     "New York",
+			expected: []porter.Token{
+				{Term: "New York", StartOffset: 0, EndOffset: 8, Type: "word", Position: 0},
+			},
+		},
+		{
+			name:     "pattern case",
+			analyzer: "pattern",
+			text:     "Mail: John.Smith@Example.com",
+			expected: []porter.Token{
+				{Term: "mail", StartOffset: 0, EndOffset: 4, Type: "word", Position: 0},
+				{Term: "john", StartOffset: 6, EndOffset: 10, Type: "word", Position: 1},
+				{Term: "smith", StartOffset: 11, EndOffset: 16, Type: "word", Position: 2},
+				{Term: "example", StartOffset: 17, EndOffset: 24, Type: "word", Position: 3},
+				{Term: "com", StartOffset: 25, EndOffset: 28, Type: "word", Position: 4},
+			},
+		},
+		{
+			name:     "stop case",
+			analyzer: "stop",
+			text:     "The quick fox is in the box",
+			expected: []porter.Token{
+				{Term: "quick", StartOffset: 4, EndOffset: 9, Type: "word", Position: 1},
+				{Term: "fox", StartOffset: 10, EndOffset: 13, Type: "word", Position: 2},
+				{Term: "box", StartOffset: 24, EndOffset: 27, Type: "word", Position: 6},
+			},
+		},
+		{
+			name:     "emoji sequence at the end case",
+			analyzer: "standard",
+			text:     "hi 👨‍👩",
+			expected: []porter.Token{
+				{Term: "hi", StartOffset: 0, EndOffset: 2, Type: "<ALPHANUM>", Position: 0},
+				{Term: "👨‍👩", StartOffset: 3, EndOffset: 8, Type: "<EMOJI>", Position: 1},
+			},
+		},
+		{
+			name:     "emoji sequence at the start case",
+			analyzer: "standard",
+			text:     "👨‍👩 hi",
+			expected: []porter.Token{
+				{Term: "👨‍👩", StartOffset: 0, EndOffset: 5, Type: "<EMOJI>", Position: 0},
+				{Term: "hi", StartOffset: 6, EndOffset: 8, Type: "<ALPHANUM>", Position: 1},
+			},
+		},
+		{
+			name:     "utf-16 offsets case",
+			analyzer: "whitespace",
+			text:     "𝒳 café",
+			expected: []porter.Token{
+				{Term: "𝒳", StartOffset: 0, EndOffset: 2, Type: "word", Position: 0},
+				{Term: "café", StartOffset: 3, EndOffset: 7, Type: "word", Position: 1},
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		s.T.Run(cs.name, func(t *testing.T) {
+			s.AssertEmulatedTokens(t, c, cs.analyzer, cs.text, cs.expected)
+		})
+	}
+}
+
+func TestEmulateCustom_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+
+	c := porter.Config{
+		Name: "porter_emulate",
+		Definition: porter.DefinitionConfig{
+			Settings: &porter.SettingsConfig{
+				Analysis: &porter.AnalysisConfig{
+					Filter: a.NewFilter(
+						a.Filter.EdgeNGram("autocomplete_filter",
+							a.Filter.EdgeNGram.WithMinGram(2),
+							a.Filter.EdgeNGram.WithMaxGram(4),
+							a.Filter.EdgeNGram.WithPreserveOriginal(true),
+						),
+						a.Filter.NGram("trigrams",
+							a.Filter.NGram.WithMinGram(2),
+							a.Filter.NGram.WithMaxGram(3),
+						),
+						a.Filter.ASCIIFolding("folding", a.Filter.ASCIIFolding.WithPreserveOriginal(true)),
+						a.Filter.Length("short", a.Filter.Length.WithMin(3)),
+						a.Filter.Stop("product_stop",
+							a.Filter.Stop.WithStopwords([]string{"The", "Of"}),
+							a.Filter.Stop.WithIgnoreCase(true),
+						),
+					),
+					Analyzer: a.NewAnalyzer(
+						a.Analyzer.Custom("autocomplete",
+							a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizerStandard),
+							a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{
+								porter.AnalyzerCustomFilterLowercase,
+								porter.AnalyzerCustomFilter("autocomplete_filter"),
+							}),
+						),
+						a.Analyzer.Custom("grams",
+							a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizerKeyword),
+							a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{porter.AnalyzerCustomFilter("trigrams")}),
+						),
+						a.Analyzer.Custom("folded",
+							a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizerWhitespace),
+							a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{
+								porter.AnalyzerCustomFilterUppercase,
+								porter.AnalyzerCustomFilter("folding"),
+							}),
+						),
+						a.Analyzer.Custom("products",
+							a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizerWhitespace),
+							a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{
+								porter.AnalyzerCustomFilter("product_stop"),
+								porter.AnalyzerCustomFilter("short"),
+								porter.AnalyzerCustomFilterTrim,
+							}),
+						),
+					),
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		analyzer string
+		text     string
+		expected []porter.Token
+	}{
+		{
+			name:     "edge ngram case",
+			analyzer: "autocomplete",
+			text:     "Quick Brownie",
+			expected: []porter.Token{
+				{Term: "qu", StartOffset: 0, EndOffset: 5, Type: "<ALPHANUM>", Position: 0},
+				{Term: "qui", StartOffset: 0, EndOffset: 5, Type: "<ALPHANUM>", Position: 0},
+				{Term: "quic", StartOffset: 0, EndOffset: 5, Type: "<ALPHANUM>", Position: 0},
+				{Term: "quick", StartOffset: 0, EndOffset: 5, Type: "<ALPHANUM>", Position: 0},
+				{Term: "br", StartOffset: 6, EndOffset: 13, Type: "<ALPHANUM>", Position: 1},
+				{Term: "bro", StartOffset: 6, EndOffset: 13, Type: "<ALPHANUM>", Position: 1},
+				{Term: "brow", StartOffset: 6, EndOffset: 13, Type: "<ALPHANUM>", Position: 1},
+				{Term: "brownie", StartOffset: 6, EndOffset: 13, Type: "<ALPHANUM>", Position: 1},
+			},
+		},
+		{
+			name:     "ngram case",
+			analyzer: "grams",
+			text:     "fox",
+			expected: []porter.Token{
+				{Term: "fo", StartOffset: 0, EndOffset: 3, Type: "word", Position: 0},
+				{Term: "fox", StartOffset: 0, EndOffset: 3, Type: "word", Position: 0},
+				{Term: "ox", StartOffset: 0, EndOffset: 3, Type: "word", Position: 0},
+			},
+		},
+		{
+			name:     "ascii folding case",
+			analyzer: "folded",
+			text:     "crème brûlée",
+			expected: []porter.Token{
+				{Term: "CREME", StartOffset: 0, EndOffset: 5, Type: "word", Position: 0},
+				{Term: "CRÈME", StartOffset: 0, EndOffset: 5, Type: "word", Position: 0},
+				{Term: "BRULEE", StartOffset: 6, EndOffset: 12, Type: "word", Position: 1},
+				{Term: "BRÛLÉE", StartOffset: 6, EndOffset: 12, Type: "word", Position: 1},
+			},
+		},
+		{
+			name:     "stop and length case",
+			analyzer: "products",
+			text:     "the Lord of XL rings",
+			expected: []porter.Token{
+				{Term: "Lord", StartOffset: 4, EndOffset: 8, Type: "word", Position: 1},
+				{Term: "rings", StartOffset: 15, EndOffset: 20, Type: "word", Position: 4},
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		s.T.Run(cs.name, func(t *testing.T) {
+			s.AssertEmulatedTokens(t, c, cs.analyzer, cs.text, cs.expected)
+		})
+	}
+}
+
+func TestEmulateUnsupported_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+
+	c := porter.Config{
+		Name: "porter_emulate",
+		Definition: porter.DefinitionConfig{
+			Settings: &porter.SettingsConfig{
+				Analysis: &porter.AnalysisConfig{
+					Tokenizer: a.NewTokenizer(
+						a.Tokenizer.Pattern("negative_group", a.Tokenizer.Pattern.WithGroup(-2)),
+					),
+					Filter: a.NewFilter(
+						a.Filter.Stop("keep_trailing", a.Filter.Stop.WithRemoveTrailing(false)),
+					),
+					Analyzer: a.NewAnalyzer(
+						a.Analyzer.Custom("negative_group",
+							a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizer("negative_group")),
+						),
+						a.Analyzer.Custom("html_english",
+							a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizerStandard),
+							a.Analyzer.Custom.WithCharFilter([]porter.AnalyzerCustomCharFilter{porter.AnalyzerCustomCharFilterHTMLStrip}),
+							a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{
+								porter.AnalyzerCustomFilterLowercase,
+								porter.AnalyzerCustomFilterStemmer,
+							}),
+						),
+						a.Analyzer.Language("english_text", porter.AnalyzerLanguageEnglish),
+						a.Analyzer.Stop("french_stop", a.Analyzer.Stop.WithStopwordsPath("stopwords/french.txt")),
+						a.Analyzer.Custom("suggest",
+							a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizerStandard),
+							a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{porter.AnalyzerCustomFilter("keep_trailing")}),
+						),
+						a.Analyzer.Custom("romanian_folded",
+							a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizerStandard),
+							a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{porter.AnalyzerCustomFilterASCIIFolding}),
+						),
+					),
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		analyzer    string
+		text        string
+		expectedErr string
+	}{
+		{
+			name:        "unsupported components case",
+			analyzer:    "html_english",
+			text:        "text",
+			expectedErr: "[char_filter [html_strip], filter [stemmer]]",
+		},
+		{
+			name:        "negative pattern group case",
+			analyzer:    "negative_group",
+			text:        "text",
+			expectedErr: "[tokenizer [negative_group]: group [-2]]",
+		},
+		{
+			name:        "language analyzer case",
+			analyzer:    "english_text",
+			text:        "text",
+			expectedErr: "[analyzer [english_text] of type [english]]",
+		},
+		{
+			name:        "stopwords path case",
+			analyzer:    "french_stop",
+			text:        "text",
+			expectedErr: "[analyzer [french_stop]: stopwords_path]",
+		},
+		{
+			name:        "undefined analyzer case",
+			analyzer:    "missing",
+			text:        "text",
+			expectedErr: "[missing]",
+		},
+		{
+			name:        "stop keeping trailing stopwords case",
+			analyzer:    "suggest",
+			text:        "text",
+			expectedErr: "[filter [keep_trailing]: remove_trailing]",
+		},
+		{
+			name:        "unmapped ascii folding case",
+			analyzer:    "romanian_folded",
+			text:        "București",
+			expectedErr: "[filter [asciifolding]: character [U+0219]]",
+		},
+	}
+
+	for _, cs := range cases {
+		s.T.Run(cs.name, func(t *testing.T) {
+			_, err := s.Porter.Emulate(c, cs.analyzer, cs.text)
+			assert.ErrorIs(t, err, porter.ErrPorterEmulating)
+			assert.ErrorContains(t, err, cs.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,514 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
+	"github.com/xoticdsign/porter2/internal/tests/suite"
+)
+
+func TestMultiFields_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	cases := []struct {
+		name        string
+		in          porter.FieldFunc
+		expected    map[string]interface{}
+		expectedDoc []string
+		excludedDoc []string
+	}{
+		{
+			name: "text with sub-fields case",
+			in: p.Text(
+				"multi_title",
+				porter.FakeJobTitle,
+				p.Text.WithFields(
+					p.Keyword("multi_raw", porter.FakeJobTitle, p.Keyword.WithIgnoreAbove(256)),
+					p.Text("multi_english", porter.FakeJobTitle, p.Text.WithAnalyzer("english")),
+				),
+			),
+			expected: map[string]interface{}{
+				"multi_title": map[string]interface{}{
+					"type": "text",
+					"fields": map[string]interface{}{
+						"multi_raw": map[string]interface{}{
+							"type":         "keyword",
+							"ignore_above": 256,
+						},
+						"multi_english": map[string]interface{}{
+							"type":     "text",
+							"analyzer": "english",
+						},
+					},
+				},
+			},
+			expectedDoc: []string{"multi_title"},
+			excludedDoc: []string{"multi_raw", "multi_english"},
+		},
+		{
+			name: "keyword with sub-field case",
+			in: p.Keyword(
+				"multi_code",
+				porter.FakeUUID,
+				p.Keyword.WithFields(
+					p.Text("multi_code_text", porter.FakeUUID),
+				),
+			),
+			expected: map[string]interface{}{
+				"multi_code": map[string]interface{}{
+					"type": "keyword",
+					"fields": map[string]interface{}{
+						"multi_code_text": map[string]interface{}{
+							"type": "text",
+						},
+					},
+				},
+			},
+			expectedDoc: []string{"multi_code"},
+			excludedDoc: []string{"multi_code_text"},
+		},
+	}
+
+	for _, cs := range cases {
+		s.T.Run(cs.name, func(t *testing.T) {
+			f := s.Porter.Index.Mappings.NewFields(cs.in)
+
+			assert.Equal(t, cs.expected, f)
+
+			docs, err := s.Porter.Documents.Origin.Generate(1)(s.Temp)
+			assert.NoError(t, err)
+
+			lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+			assert.Len(t, lines, 2)
+
+			var doc map[string]interface{}
+
+			err = json.Unmarshal(lines[1], &doc)
+			assert.NoError(t, err)
+
+			for _, k := range cs.expectedDoc {
+				assert.Contains(t, doc, k)
+			}
+			for _, k := range cs.excludedDoc {
+				assert.NotContains(t, doc, k)
+			}
+		})
+	}
+}
+
+func TestMultiFieldsNameCollision_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	s.Porter.Index.Mappings.NewFields(
+		p.Keyword("shared_english", porter.FakeCity),
+		p.Text(
+			"shared_title",
+			porter.FakeJobTitle,
+			p.Text.WithFields(
+				p.Text("shared_english", porter.FakeJobTitle, p.Text.WithAnalyzer("english")),
+			),
+		),
+	)
+
+	docs, err := s.Porter.Documents.Origin.Generate(1)(s.Temp)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+	assert.Len(t, lines, 2)
+
+	var doc map[string]interface{}
+
+	err = json.Unmarshal(lines[1], &doc)
+	assert.NoError(t, err)
+
+	assert.Contains(t, doc, "shared_title")
+	assert.Contains(t, doc, "shared_english")
+}
+
+func TestUnplacedFields_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	unplaced := p.Keyword("unplaced_code", porter.FakeUUID)
+	assert.Equal(t, map[string]interface{}{
+		"unplaced_code": map[string]interface{}{"type": "keyword"},
+	}, unplaced())
+
+	placed := p.Keyword("placed_code", porter.FakeUUID)
+	s.Porter.Index.Mappings.NewFields(placed)
+	s.Porter.Index.Mappings.NewFields(placed)
+
+	docs, err := s.Porter.Documents.Origin.Generate(1)(s.Temp)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+	assert.Len(t, lines, 2)
+
+	var doc map[string]interface{}
+
+	err = json.Unmarshal(lines[1], &doc)
+	assert.NoError(t, err)
+
+	assert.Contains(t, doc, "placed_code")
+	assert.NotContains(t, doc, "unplaced_code")
+}
+
+func TestObjectAndNested_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	f := s.Porter.Index.Mappings.NewFields(
+		p.Object(
+			"order_customer",
+			p.Object.WithDynamic(porter.FieldDynamicStrict),
+			p.Object.WithProperties(
+				p.Keyword("order_customer_email", porter.FakeEmail),
+			),
+		),
+		p.Nested(
+			"order_line_items",
+			porter.FakeCardinality{Min: 2, Max: 3},
+			p.Nested.WithIncludeInParent(true),
+			p.Nested.WithProperties(
+				p.Keyword("order_sku", porter.FakeUUID),
+				p.Integer("order_quantity", porter.FakeIntegerInt),
+			),
+		),
+	)
+
+	assert.Equal(t, map[string]interface{}{
+		"order_customer": map[string]interface{}{
+			"type":    "object",
+			"dynamic": porter.FieldDynamicStrict,
+			"properties": map[string]interface{}{
+				"order_customer_email": map[string]interface{}{"type": "keyword"},
+			},
+		},
+		"order_line_items": map[string]interface{}{
+			"type":              "nested",
+			"include_in_parent": true,
+			"properties": map[string]interface{}{
+				"order_sku":      map[string]interface{}{"type": "keyword"},
+				"order_quantity": map[string]interface{}{"type": "integer"},
+			},
+		},
+	}, f)
+
+	docs, err := s.Porter.Documents.Origin.Generate(1)(s.Temp)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+	assert.Len(t, lines, 2)
+
+	var doc map[string]interface{}
+
+	err = json.Unmarshal(lines[1], &doc)
+	assert.NoError(t, err)
+
+	assert.NotContains(t, doc, "order_customer_email")
+	assert.NotContains(t, doc, "order_sku")
+
+	customer, ok := doc["order_customer"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Contains(t, customer, "order_customer_email")
+
+	items, ok := doc["order_line_items"].([]interface{})
+	assert.True(t, ok)
+	assert.GreaterOrEqual(t, len(items), 2)
+	assert.LessOrEqual(t, len(items), 3)
+
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		assert.True(t, ok)
+		assert.Contains(t, m, "order_sku")
+		assert.Contains(t, m, "order_quantity")
+	}
+}
+
+func TestObjectChildNameCollision_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	s.Porter.Index.Mappings.NewFields(
+		p.Keyword("shared_name", porter.FakeCity),
+		p.Object(
+			"shared_author",
+			p.Object.WithProperties(
+				p.Keyword("shared_name", porter.FakeFullName),
+			),
+		),
+		p.Nested(
+			"shared_editors",
+			porter.FakeCardinality{Min: 1, Max: 2},
+			p.Nested.WithProperties(
+				p.Keyword("shared_name", porter.FakeFullName),
+			),
+		),
+	)
+
+	docs, err := s.Porter.Documents.Origin.Generate(1)(s.Temp)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+	assert.Len(t, lines, 2)
+
+	var doc map[string]interface{}
+
+	err = json.Unmarshal(lines[1], &doc)
+	assert.NoError(t, err)
+
+	assert.Contains(t, doc, "shared_name")
+
+	author, ok := doc["shared_author"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Contains(t, author, "shared_name")
+
+	editors, ok := doc["shared_editors"].([]interface{})
+	assert.True(t, ok)
+
+	for _, editor := range editors {
+		m, ok := editor.(map[string]interface{})
+		assert.True(t, ok)
+		assert.Contains(t, m, "shared_name")
+	}
+}
+
+func TestCompletion_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	s.Porter.Index.Mappings.NewFields(
+		p.Completion(
+			"typeahead_suggest",
+			porter.FakeCompletion{
+				Input:      porter.FakeCompany,
+				Categories: []string{"shoes", "bags"},
+				Location:   porter.FakeGeoPointAround(porter.FakeGeoCityParis, 5),
+			},
+			p.Completion.WithMaxInputLength(50),
+			p.Completion.WithContexts([]porter.FieldCompletionContext{
+				{Name: "category", Type: porter.FieldCompletionContextTypeCategory},
+				{Name: "location", Type: porter.FieldCompletionContextTypeGeo, Precision: 4},
+				{Name: "brand", Type: porter.FieldCompletionContextTypeCategory, Path: "brand"},
+			}),
+		),
+	)
+
+	docs, err := s.Porter.Documents.Origin.Generate(1)(s.Temp)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+	assert.Len(t, lines, 2)
+
+	var doc map[string]interface{}
+
+	err = json.Unmarshal(lines[1], &doc)
+	assert.NoError(t, err)
+
+	suggest, ok := doc["typeahead_suggest"].(map[string]interface{})
+	assert.True(t, ok)
+
+	assert.NotEmpty(t, suggest["input"])
+	assert.NotZero(t, suggest["weight"])
+
+	contexts, ok := suggest["contexts"].(map[string]interface{})
+	assert.True(t, ok)
+
+	assert.Contains(t, []interface{}{"shoes", "bags"}, contexts["category"].([]interface{})[0])
+	assert.Contains(t, contexts["location"], "lat")
+	assert.Contains(t, contexts["location"], "lon")
+	assert.NotContains(t, contexts, "brand")
+}
+
+func TestKeywordFamily_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	f := s.Porter.Index.Mappings.NewFields(
+		p.Wildcard("family_message", porter.FakeWildcardLogLine),
+		p.ConstantKeyword("family_dataset", "nginx.access"),
+		p.Flattened("family_labels", porter.FakeFlattenedObject, p.Flattened.WithDepthLimit(2)),
+		p.MatchOnlyText("family_body", porter.FakeParagraph),
+	)
+
+	assert.Equal(t, map[string]interface{}{
+		"type":  "constant_keyword",
+		"value": "nginx.access",
+	}, f["family_dataset"])
+
+	var depth func(v interface{}) int
+
+	depth = func(v interface{}) int {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return 0
+		}
+
+		max := 0
+		for _, child := range m {
+			if d := depth(child); d > max {
+				max = d
+			}
+		}
+
+		return max + 1
+	}
+
+	for c := 0; c < 20; c++ {
+		docs, err := s.Porter.Documents.Origin.Generate(1)(s.Temp)
+		assert.NoError(t, err)
+
+		lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+		assert.Len(t, lines, 2)
+
+		var doc map[string]interface{}
+
+		err = json.Unmarshal(lines[1], &doc)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "nginx.access", doc["family_dataset"])
+		assert.NotEmpty(t, doc["family_message"])
+		assert.NotEmpty(t, doc["family_body"])
+		assert.LessOrEqual(t, depth(doc["family_labels"]), 2)
+	}
+}
+
+func TestMiscFields_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	f := s.Porter.Index.Mappings.NewFields(
+		p.Histogram("misc_latency", porter.FakeHistogramValues),
+		p.AggregateMetricDouble(
+			"misc_agg",
+			porter.FakeAggregateMetricDoubleMetrics,
+			[]porter.FieldAggregateMetric{porter.FieldAggregateMetricMin, porter.FieldAggregateMetricMax, porter.FieldAggregateMetricSum, porter.FieldAggregateMetricValueCount},
+			porter.FieldAggregateMetricMax,
+		),
+		p.Version("misc_version", porter.FakeVersionSemver),
+		p.Binary("misc_blob", porter.FakeBinaryBase64),
+		p.TokenCount("misc_words", porter.FakeParagraph, "standard"),
+		p.Alias("misc_alias", "misc_version"),
+	)
+
+	assert.Equal(t, map[string]interface{}{
+		"type": "alias",
+		"path": "misc_version",
+	}, f["misc_alias"])
+
+	for c := 0; c < 20; c++ {
+		docs, err := s.Porter.Documents.Origin.Generate(1)(s.Temp)
+		assert.NoError(t, err)
+
+		lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+		assert.Len(t, lines, 2)
+
+		var doc map[string]interface{}
+
+		err = json.Unmarshal(lines[1], &doc)
+		assert.NoError(t, err)
+
+		assert.NotContains(t, doc, "misc_alias")
+		assert.Regexp(t, `^\d+\.\d+\.\d+(-[a-z]+\.\d+)?$`, doc["misc_version"])
+
+		_, err = base64.StdEncoding.DecodeString(doc["misc_blob"].(string))
+		assert.NoError(t, err)
+
+		h := doc["misc_latency"].(map[string]interface{})
+		values := h["values"].([]interface{})
+		counts := h["counts"].([]interface{})
+		assert.Equal(t, len(values), len(counts))
+		for i := 1; i < len(values); i++ {
+			assert.Greater(t, values[i].(float64), values[i-1].(float64))
+		}
+
+		agg := doc["misc_agg"].(map[string]interface{})
+		min, max := agg["min"].(float64), agg["max"].(float64)
+		sum, count := agg["sum"].(float64), agg["value_count"].(float64)
+		assert.LessOrEqual(t, min, max)
+		assert.GreaterOrEqual(t, sum, min*count-0.01)
+		assert.LessOrEqual(t, sum, max*count+0.01)
+	}
+}
+
+func TestFieldScript_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	script := porter.ScriptConfig{
+		Source: "emit(doc['script_price'].value * params.rate)",
+		Lang:   "painless",
+		Params: map[string]interface{}{"rate": 1.2},
+	}
+
+	f := s.Porter.Index.Mappings.NewFields(
+		p.Double("script_price", porter.FakeDoubleFloat),
+		p.Double("script_gross", porter.FakeDoubleFloat,
+			p.Double.WithScript(script),
+			p.Double.WithOnScriptError(porter.FieldOnScriptErrorContinue),
+		),
+	)
+
+	assert.Equal(t, map[string]interface{}{
+		"type":            "double",
+		"script":          script,
+		"on_script_error": porter.FieldOnScriptErrorContinue,
+	}, f["script_gross"])
+
+	docs, err := s.Porter.Documents.Origin.Generate(1)(s.Temp)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(docs), []byte("\n"))
+	assert.Len(t, lines, 2)
+
+	var doc map[string]interface{}
+
+	err = json.Unmarshal(lines[1], &doc)
+	assert.NoError(t, err)
+
+	assert.Contains(t, doc, "script_price")
+	assert.NotContains(t, doc, "script_gross")
+}
@@ -0,0 +1,201 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
+	"github.com/xoticdsign/porter2/internal/tests/suite"
+)
+
+func TestNewFilter_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	f := s.Porter.Index.Settings.Analysis.Filter
+
+	cases := []struct {
+		name     string
+		in       porter.FilterFunc
+		expected map[string]interface{}
+	}{
+		{
+			name: "inline synonyms case",
+			in: f.Synonym("product_synonyms",
+				f.Synonym.WithSynonyms([]string{"tv, television", "laptop => notebook"}),
+				f.Synonym.WithLenient(true),
+			),
+			expected: map[string]interface{}{
+				"product_synonyms": map[string]interface{}{
+					"type":     "synonym",
+					"synonyms": []string{"tv, television", "laptop => notebook"},
+					"lenient":  true,
+				},
+			},
+		},
+		{
+			name: "path synonym graph case",
+			in:   f.SynonymGraph("file_synonyms", f.SynonymGraph.WithSynonymsPath("analysis/synonyms.txt"), f.SynonymGraph.WithExpand(false)),
+			expected: map[string]interface{}{
+				"file_synonyms": map[string]interface{}{
+					"type":          "synonym_graph",
+					"synonyms_path": "analysis/synonyms.txt",
+					"expand":        false,
+				},
+			},
+		},
+		{
+			name: "stemmer case",
+			in:   f.Stemmer("light_english", porter.FilterStemmerLightEnglish),
+			expected: map[string]interface{}{
+				"light_english": map[string]interface{}{
+					"type":     "stemmer",
+					"language": porter.FilterStemmerLightEnglish,
+				},
+			},
+		},
+		{
+			name: "shingle case",
+			in: f.Shingle("bigrams",
+				f.Shingle.WithMinShingleSize(2),
+				f.Shingle.WithMaxShingleSize(2),
+				f.Shingle.WithOutputUnigrams(false),
+			),
+			expected: map[string]interface{}{
+				"bigrams": map[string]interface{}{
+					"type":             "shingle",
+					"min_shingle_size": 2,
+					"max_shingle_size": 2,
+					"output_unigrams":  false,
+				},
+			},
+		},
+		{
+			name: "keep words case",
+			in:   f.KeepWords("colors", f.KeepWords.WithKeepWords([]string{"red", "green", "blue"}), f.KeepWords.WithKeepWordsCase(true)),
+			expected: map[string]interface{}{
+				"colors": map[string]interface{}{
+					"type":            "keep",
+					"keep_words":      []string{"red", "green", "blue"},
+					"keep_words_case": true,
+				},
+			},
+		},
+		{
+			name: "pattern replace case",
+			in:   f.PatternReplace("sku_punctuation", `[^a-zA-Z0-9]`, f.PatternReplace.WithReplacement(""), f.PatternReplace.WithAll(true)),
+			expected: map[string]interface{}{
+				"sku_punctuation": map[string]interface{}{
+					"type":        "pattern_replace",
+					"pattern":     `[^a-zA-Z0-9]`,
+					"replacement": "",
+					"all":         true,
+				},
+			},
+		},
+		{
+			name: "word delimiter graph case",
+			in: f.WordDelimiterGraph("parts",
+				f.WordDelimiterGraph.WithPreserveOriginal(true),
+				f.WordDelimiterGraph.WithProtectedWords([]string{"wi-fi"}),
+				f.WordDelimiterGraph.WithSplitOnCaseChange(false),
+			),
+			expected: map[string]interface{}{
+				"parts": map[string]interface{}{
+					"type":                 "word_delimiter_graph",
+					"preserve_original":    true,
+					"protected_words":      []string{"wi-fi"},
+					"split_on_case_change": false,
+				},
+			},
+		},
+		{
+			name: "stop, length and elision case",
+			in: func() map[string]interface{} {
+				r := map[string]interface{}{}
+				for _, fn := range []porter.FilterFunc{
+					f.Stop("stop_en", f.Stop.WithStopwords([]string{"_english_"}), f.Stop.WithIgnoreCase(true)),
+					f.Length("not_too_long", f.Length.WithMin(2), f.Length.WithMax(20)),
+					f.Elision("french_elision", f.Elision.WithArticles([]string{"l", "d"}), f.Elision.WithArticlesCase(true)),
+				} {
+					for k, v := range fn() {
+						r[k] = v
+					}
+				}
+				return r
+			},
+			expected: map[string]interface{}{
+				"stop_en": map[string]interface{}{
+					"type":        "stop",
+					"stopwords":   []string{"_english_"},
+					"ignore_case": true,
+				},
+				"not_too_long": map[string]interface{}{
+					"type": "length",
+					"min":  2,
+					"max":  20,
+				},
+				"french_elision": map[string]interface{}{
+					"type":          "elision",
+					"articles":      []string{"l", "d"},
+					"articles_case": true,
+				},
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		s.T.Run(cs.name, func(t *testing.T) {
+			r := s.Porter.Index.Settings.Analysis.NewFilter(cs.in)
+
+			assert.Equal(t, cs.expected, r)
+		})
+	}
+}
+
+func TestFilterReferences_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+
+	analysis := &porter.AnalysisConfig{
+		Filter: a.NewFilter(
+			a.Filter.Synonym("product_synonyms", a.Filter.Synonym.WithSynonyms([]string{"tv, television"})),
+			a.Filter.PatternReplace("sku_punctuation", `[^a-zA-Z0-9]`, a.Filter.PatternReplace.WithReplacement("")),
+			a.Filter.NGram("grams", a.Filter.NGram.WithMinGram(2), a.Filter.NGram.WithMaxGram(3)),
+		),
+		Analyzer: a.NewAnalyzer(a.Analyzer.Custom("products",
+			a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizerStandard),
+			a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{porter.AnalyzerCustomFilterLowercase, "product_synonyms", "grams"}),
+		)),
+		Normalizer: a.NewNormalizer(a.Normalizer.Custom("sku",
+			a.Normalizer.Custom.WithFilter([]porter.NormalizerCustomFilter{"sku_punctuation", porter.NormalizerCustomFilterUppercase}),
+		)),
+	}
+
+	assert.Equal(t, []porter.AnalyzerCustomFilter{"lowercase", "product_synonyms", "grams"}, analysis.Analyzer["products"].(map[string]interface{})["filter"])
+	assert.Equal(t, []porter.NormalizerCustomFilter{"sku_punctuation", "uppercase"}, analysis.Normalizer["sku"].(map[string]interface{})["filter"])
+
+	c := porter.Config{
+		Name: "porter_filters",
+		Definition: porter.DefinitionConfig{
+			Settings: &porter.SettingsConfig{
+				Analysis: analysis,
+			},
+		},
+	}
+
+	err = s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndex(), s.Porter.Documents.NoDocuments())
+	assert.NoError(t, err)
+
+	analysis.Filter = a.NewFilter(a.Filter.NGram("grams", a.Filter.NGram.WithMinGram(2), a.Filter.NGram.WithMaxGram(5)))
+
+	err = s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndex(), s.Porter.Documents.NoDocuments())
+	assert.ErrorContains(t, err, "filter [grams]: the difference between max_gram and min_gram [3] must be less than or equal to max_ngram_diff [1]")
+}
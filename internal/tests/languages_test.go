@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
+	"github.com/xoticdsign/porter2/internal/tests/suite"
+)
+
+func TestRebuildLanguage_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+
+	cases := []struct {
+		name             string
+		in               porter.AnalyzerLanguageRebuilt
+		expectedAnalyzer map[string]interface{}
+		expectedFilter   map[string]interface{}
+	}{
+		{
+			name: "english with ascii folding case",
+			in: a.Analyzer.Language.Rebuild("english_folded", porter.AnalyzerLanguageEnglish,
+				a.Analyzer.Language.WithStemExclusion([]string{"skies"}),
+			).WithFilterAfter(porter.AnalyzerCustomFilterLowercase, porter.AnalyzerCustomFilterASCIIFolding),
+			expectedAnalyzer: map[string]interface{}{
+				"english_folded": map[string]interface{}{
+					"type":      "custom",
+					"tokenizer": porter.AnalyzerCustomTokenizerStandard,
+					"filter": []porter.AnalyzerCustomFilter{
+						"english_folded_possessive_stemmer",
+						porter.AnalyzerCustomFilterLowercase,
+						porter.AnalyzerCustomFilterASCIIFolding,
+						"english_folded_stop",
+						"english_folded_keywords",
+						"english_folded_stemmer",
+					},
+				},
+			},
+			expectedFilter: map[string]interface{}{
+				"english_folded_possessive_stemmer": map[string]interface{}{"type": "stemmer", "language": porter.FilterStemmerPossessiveEnglish},
+				"english_folded_stop":               map[string]interface{}{"type": "stop", "stopwords": "_english_"},
+				"english_folded_keywords":           map[string]interface{}{"type": "keyword_marker", "keywords": []string{"skies"}},
+				"english_folded_stemmer":            map[string]interface{}{"type": "stemmer", "language": porter.FilterStemmerEnglish},
+			},
+		},
+		{
+			name: "french with replaced stemmer case",
+			in: a.Analyzer.Language.Rebuild("french_minimal", porter.AnalyzerLanguageFrench,
+				a.Analyzer.Language.WithStopwords([]string{"le", "la"}),
+			).WithFilterDefinition(
+				a.Filter.Stemmer("french_minimal_stemmer", porter.FilterStemmerMinimalFrench),
+			).WithoutFilter("french_minimal_elision"),
+			expectedAnalyzer: map[string]interface{}{
+				"french_minimal": map[string]interface{}{
+					"type":      "custom",
+					"tokenizer": porter.AnalyzerCustomTokenizerStandard,
+					"filter": []porter.AnalyzerCustomFilter{
+						porter.AnalyzerCustomFilterLowercase,
+						"french_minimal_stop",
+						"french_minimal_stemmer",
+					},
+				},
+			},
+			expectedFilter: map[string]interface{}{
+				"french_minimal_stop":    map[string]interface{}{"type": "stop", "stopwords": []string{"le", "la"}},
+				"french_minimal_stemmer": map[string]interface{}{"type": "stemmer", "language": porter.FilterStemmerMinimalFrench},
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		s.T.Run(cs.name, func(t *testing.T) {
+			assert.Equal(t, cs.expectedAnalyzer, a.NewAnalyzer(cs.in.Analyzer()))
+			assert.Equal(t, cs.expectedFilter, a.NewFilter(cs.in.Filter()))
+
+			c := porter.Config{
+				Name: "porter_languages",
+				Definition: porter.DefinitionConfig{
+					Settings: &porter.SettingsConfig{
+						Analysis: &porter.AnalysisConfig{
+							Analyzer:   a.NewAnalyzer(cs.in.Analyzer()),
+							Filter:     a.NewFilter(cs.in.Filter()),
+							CharFilter: a.NewCharFilter(cs.in.CharFilter()),
+						},
+					},
+				},
+			}
+
+			assert.True(t, s.Porter.Validate(c).Valid())
+		})
+	}
+}
+
+func TestRebuildLanguageAll_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+
+	languages := []porter.AnalyzerLanguageLanguage{
+		porter.AnalyzerLanguageArabic, porter.AnalyzerLanguageCJK, porter.AnalyzerLanguageDutch, porter.AnalyzerLanguageGerman,
+		porter.AnalyzerLanguageIrish, porter.AnalyzerLanguagePersian, porter.AnalyzerLanguageThai, porter.AnalyzerLanguageTurkish,
+	}
+
+	for _, l := range languages {
+		r := a.Analyzer.Language.Rebuild("rebuilt", l, a.Analyzer.Language.WithStemExclusion([]string{"example"}))
+
+		c := porter.Config{
+			Name: "porter_languages",
+			Definition: porter.DefinitionConfig{
+				Settings: &porter.SettingsConfig{
+					Analysis: &porter.AnalysisConfig{
+						Analyzer:   a.NewAnalyzer(r.Analyzer()),
+						Filter:     a.NewFilter(r.Filter()),
+						CharFilter: a.NewCharFilter(r.CharFilter()),
+					},
+				},
+			},
+		}
+
+		assert.Empty(t, s.Porter.Validate(c).Problems, string(l))
+	}
+}
+
+func TestRebuildLanguageNames_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+
+	titles := a.Analyzer.Language.Rebuild("english_titles", porter.AnalyzerLanguageEnglish,
+		a.Analyzer.Language.WithStopwords([]string{"the"}),
+	)
+	bodies := a.Analyzer.Language.Rebuild("english_bodies", porter.AnalyzerLanguageEnglish,
+		a.Analyzer.Language.WithStemExclusion([]string{"news"}),
+	)
+
+	filters := a.NewFilter(titles.Filter(), bodies.Filter())
+
+	assert.Equal(t, map[string]interface{}{"type": "stop", "stopwords": []string{"the"}}, filters["english_titles_stop"])
+	assert.Equal(t, map[string]interface{}{"type": "stop", "stopwords": "_english_"}, filters["english_bodies_stop"])
+	assert.Equal(t, map[string]interface{}{"type": "keyword_marker", "keywords": []string{"news"}}, filters["english_bodies_keywords"])
+	assert.NotContains(t, filters, "english_titles_keywords")
+
+	assert.Equal(t, map[string]interface{}{
+		"english_titles": map[string]interface{}{
+			"type":      "custom",
+			"tokenizer": porter.AnalyzerCustomTokenizerStandard,
+			"filter": []porter.AnalyzerCustomFilter{
+				"english_titles_possessive_stemmer",
+				porter.AnalyzerCustomFilterLowercase,
+				"english_titles_stop",
+				"english_titles_stemmer",
+			},
+		},
+	}, a.NewAnalyzer(titles.Analyzer()))
+
+	cjk := a.Analyzer.Language.Rebuild("cjk_rebuilt", porter.AnalyzerLanguageCJK,
+		a.Analyzer.Language.WithStemExclusion([]string{"example"}),
+	)
+
+	assert.Equal(t, map[string]interface{}{
+		"cjk_rebuilt_stop": map[string]interface{}{"type": "stop", "stopwords": "_cjk_"},
+	}, a.NewFilter(cjk.Filter()))
+}
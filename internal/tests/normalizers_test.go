@@ -79,6 +79,15 @@ func TestNewNormalizer_Functional(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "lowercase case",
+			in:   s.Porter.Index.Settings.Analysis.Normalizer.Lowercase("normalizer"),
+			expected: map[string]interface{}{
+				"normalizer": map[string]interface{}{
+					"type": "lowercase",
+				},
+			},
+		},
 		{
 			name: "empty normalizer case",
 			in:   s.Porter.Index.Settings.Analysis.Normalizer.Custom("normalizer"),
@@ -98,3 +107,87 @@ func TestNewNormalizer_Functional(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizerReferences_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+	p := s.Porter.Index.Mappings.Properties
+
+	safe := []porter.NormalizerCustomFilter{porter.NormalizerCustomFilterUppercase, "sku_punctuation"}
+
+	cases := []struct {
+		name        string
+		filter      porter.FilterFunc
+		filters     []porter.NormalizerCustomFilter
+		expectedErr []string
+	}{
+		{
+			name:    "pattern replace case",
+			filter:  a.Filter.PatternReplace("sku_punctuation", "[^A-Za-z0-9]", a.Filter.PatternReplace.WithReplacement("")),
+			filters: safe,
+		},
+		{
+			name:        "unsafe configured filter case",
+			filter:      a.Filter.Shingle("sku_punctuation"),
+			filters:     safe,
+			expectedErr: []string{"normalizer [sku]: filter [sku_punctuation] of type [shingle] cannot be used in a normalizer"},
+		},
+		{
+			name:    "unsafe filters case",
+			filter:  a.Filter.PatternReplace("sku_punctuation", "[^A-Za-z0-9]", a.Filter.PatternReplace.WithReplacement("")),
+			filters: []porter.NormalizerCustomFilter{porter.NormalizerCustomFilterLowercase, "stemmer", "sku_punctuation", "ngram"},
+			expectedErr: []string{
+				"normalizer [sku]: filter [stemmer] cannot be used in a normalizer",
+				"normalizer [sku]: filter [ngram] cannot be used in a normalizer",
+			},
+		},
+		{
+			name:    "configured filter named like a built-in case",
+			filter:  a.Filter.PatternReplace("length", "[^A-Za-z0-9]", a.Filter.PatternReplace.WithReplacement("")),
+			filters: []porter.NormalizerCustomFilter{porter.NormalizerCustomFilterLowercase, "length"},
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			c := porter.Config{
+				Name: "porter_normalizers",
+				Definition: porter.DefinitionConfig{
+					Settings: &porter.SettingsConfig{
+						Analysis: &porter.AnalysisConfig{
+							Filter: a.NewFilter(cs.filter),
+							Normalizer: a.NewNormalizer(
+								a.Normalizer.Custom("sku",
+									a.Normalizer.Custom.WithFilter(cs.filters),
+								),
+							),
+						},
+					},
+					Mappings: &porter.MappingsConfig{
+						Properties: s.Porter.Index.Mappings.NewFields(
+							p.Keyword("normalizers_sku", porter.FakeUUID, p.Keyword.WithNormalizer("sku")),
+							p.Keyword("normalizers_tag", porter.FakeJobTitle, p.Keyword.WithNormalizer(porter.NormalizerBuiltinLowercase)),
+						),
+					},
+				},
+			}
+
+			err := s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndex(), s.Porter.Documents.NoDocuments())
+
+			if len(cs.expectedErr) == 0 {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, porter.ErrPorterMigratingUp)
+			for _, e := range cs.expectedErr {
+				assert.ErrorContains(t, err, e)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
+	"github.com/xoticdsign/porter2/internal/tests/suite"
+)
+
+func TestPluginBuilders_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+
+	tokenizers := a.NewTokenizer(
+		a.Tokenizer.Kuromoji("ja_tokenizer",
+			a.Tokenizer.Kuromoji.WithMode(porter.TokenizerKuromojiModeSearch),
+			a.Tokenizer.Kuromoji.WithUserDictionaryRules([]string{"東京スカイツリー,東京 スカイツリー,トウキョウ スカイツリー,カスタム名詞"}),
+		),
+		a.Tokenizer.Nori("ko_tokenizer", a.Tokenizer.Nori.WithDecompoundMode(porter.TokenizerNoriDecompoundModeMixed)),
+		a.Tokenizer.ICU("icu", a.Tokenizer.ICU.WithRuleFiles("Latn:KeywordTokenizer.rbbi")),
+	)
+
+	assert.Equal(t, map[string]interface{}{
+		"ja_tokenizer": map[string]interface{}{
+			"type":                  "kuromoji_tokenizer",
+			"mode":                  porter.TokenizerKuromojiModeSearch,
+			"user_dictionary_rules": []string{"東京スカイツリー,東京 スカイツリー,トウキョウ スカイツリー,カスタム名詞"},
+		},
+		"ko_tokenizer": map[string]interface{}{
+			"type":            "nori_tokenizer",
+			"decompound_mode": porter.TokenizerNoriDecompoundModeMixed,
+		},
+		"icu": map[string]interface{}{
+			"type":       "icu_tokenizer",
+			"rule_files": "Latn:KeywordTokenizer.rbbi",
+		},
+	}, tokenizers)
+
+	filters := a.NewFilter(
+		a.Filter.ICUFolding("swedish_folding", a.Filter.ICUFolding.WithUnicodeSetFilter("[^åäöÅÄÖ]")),
+		a.Filter.ICUNormalizer("nfc", a.Filter.ICUNormalizer.WithName(porter.FilterICUNormalizerNameNFC)),
+		a.Filter.ICUCollation("german_collation",
+			a.Filter.ICUCollation.WithLanguage("de"),
+			a.Filter.ICUCollation.WithStrength(porter.FilterICUCollationStrengthPrimary),
+		),
+		a.Filter.Phonetic("names", porter.FilterPhoneticEncoderDoubleMetaphone, a.Filter.Phonetic.WithReplace(false)),
+	)
+
+	assert.Equal(t, map[string]interface{}{
+		"swedish_folding":  map[string]interface{}{"type": "icu_folding", "unicode_set_filter": "[^åäöÅÄÖ]"},
+		"nfc":              map[string]interface{}{"type": "icu_normalizer", "name": porter.FilterICUNormalizerNameNFC},
+		"german_collation": map[string]interface{}{"type": "icu_collation", "language": "de", "strength": porter.FilterICUCollationStrengthPrimary},
+		"names":            map[string]interface{}{"type": "phonetic", "encoder": porter.FilterPhoneticEncoderDoubleMetaphone, "replace": false},
+	}, filters)
+}
+
+func TestCheckPlugins_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+	p := s.Porter.Index.Mappings.Properties
+
+	cases := []struct {
+		name        string
+		in          porter.DefinitionConfig
+		expectedErr string
+	}{
+		{
+			name: "no plugins case",
+			in: porter.DefinitionConfig{
+				Mappings: &porter.MappingsConfig{
+					Properties: s.Porter.Index.Mappings.NewFields(p.Text("plugins_title", porter.FakeJobTitle, p.Text.WithAnalyzer("english"))),
+				},
+			},
+		},
+		{
+			name: "missing plugins case",
+			in: porter.DefinitionConfig{
+				Settings: &porter.SettingsConfig{
+					Analysis: &porter.AnalysisConfig{
+						Tokenizer: a.NewTokenizer(a.Tokenizer.Kuromoji("ja_tokenizer")),
+						Analyzer: a.NewAnalyzer(a.Analyzer.Custom("ja",
+							a.Analyzer.Custom.WithTokenizer("ja_tokenizer"),
+							a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{porter.AnalyzerCustomFilterKuromojiBaseForm, porter.AnalyzerCustomFilterICUFolding}),
+						)),
+					},
+				},
+				Mappings: &porter.MappingsConfig{
+					Properties: s.Porter.Index.Mappings.NewFields(
+						p.Text("plugins_ja", porter.FakeJobTitle, p.Text.WithAnalyzer("ja")),
+						p.Text("plugins_ko", porter.FakeJobTitle, p.Text.WithAnalyzer("nori")),
+					),
+				},
+			},
+			expectedErr: "plugins: index definition uses analysis plugins that are not installed [analysis-icu, analysis-kuromoji, analysis-nori]",
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			c := porter.Config{
+				Name:       "porter_plugins",
+				Definition: cs.in,
+			}
+
+			assert.True(t, s.Porter.Validate(c).Valid())
+
+			err := s.Porter.CheckPlugins(context.Background(), c)
+			migrateErr := s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndex(), s.Porter.Documents.NoDocuments())
+
+			if cs.expectedErr == "" {
+				assert.NoError(t, err)
+				assert.NoError(t, migrateErr)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, porter.ErrPorterPlugins)
+			assert.ErrorContains(t, err, cs.expectedErr)
+			assert.ErrorIs(t, migrateErr, porter.ErrPorterMigratingUp)
+			assert.ErrorContains(t, migrateErr, cs.expectedErr)
+		})
+	}
+}
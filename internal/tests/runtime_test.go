@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
+	"github.com/xoticdsign/porter2/internal/tests/suite"
+)
+
+func TestNewRuntime_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	script := porter.ScriptConfig{
+		Source: "emit(doc['price'].value * params.rate)",
+		Params: map[string]interface{}{"rate": 1.2},
+	}
+
+	cases := []struct {
+		name     string
+		in       []porter.RuntimeFunc
+		expected map[string]interface{}
+	}{
+		{
+			name: "scripted field case",
+			in: []porter.RuntimeFunc{
+				s.Porter.Index.Mappings.Runtime.Double(
+					"price_with_tax",
+					s.Porter.Index.Mappings.Runtime.Double.WithScript(script),
+					s.Porter.Index.Mappings.Runtime.Double.WithOnScriptError(porter.RuntimeOnScriptErrorContinue),
+				),
+			},
+			expected: map[string]interface{}{
+				"price_with_tax": map[string]interface{}{
+					"type":            "double",
+					"script":          script,
+					"on_script_error": porter.RuntimeOnScriptErrorContinue,
+				},
+			},
+		},
+		{
+			name: "source field case",
+			in: []porter.RuntimeFunc{
+				s.Porter.Index.Mappings.Runtime.Keyword("city"),
+				s.Porter.Index.Mappings.Runtime.Date("created", s.Porter.Index.Mappings.Runtime.Date.WithFormat("yyyy-MM-dd")),
+			},
+			expected: map[string]interface{}{
+				"city": map[string]interface{}{
+					"type": "keyword",
+				},
+				"created": map[string]interface{}{
+					"type":   "date",
+					"format": "yyyy-MM-dd",
+				},
+			},
+		},
+		{
+			name: "composite field case",
+			in: []porter.RuntimeFunc{
+				s.Porter.Index.Mappings.Runtime.Composite(
+					"http",
+					script,
+					s.Porter.Index.Mappings.Runtime.Composite.WithFields(map[string]porter.RuntimeCompositeFieldType{
+						"clientip": porter.RuntimeCompositeFieldTypeIP,
+						"verb":     porter.RuntimeCompositeFieldTypeKeyword,
+					}),
+				),
+			},
+			expected: map[string]interface{}{
+				"http": map[string]interface{}{
+					"type":   "composite",
+					"script": script,
+					"fields": map[string]interface{}{
+						"clientip": map[string]interface{}{"type": porter.RuntimeCompositeFieldTypeIP},
+						"verb":     map[string]interface{}{"type": porter.RuntimeCompositeFieldTypeKeyword},
+					},
+				},
+			},
+		},
+		{
+			name:     "nil field case",
+			in:       []porter.RuntimeFunc{nil},
+			expected: map[string]interface{}{},
+		},
+	}
+
+	for _, cs := range cases {
+		s.T.Run(cs.name, func(t *testing.T) {
+			r := s.Porter.Index.Mappings.NewRuntime(cs.in...)
+
+			assert.Equal(t, cs.expected, r)
+		})
+	}
+}
+
+func TestSearch_Integration(t *testing.T) {
+	s, err := suite.New(t, false)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+	r := s.Porter.Index.Mappings.Runtime
+
+	c := porter.Config{
+		Name: "porter_search",
+		Definition: porter.DefinitionConfig{
+			Mappings: &porter.MappingsConfig{
+				Properties: s.Porter.Index.Mappings.NewFields(
+					p.Long("search_price", porter.FakeLongInt),
+				),
+			},
+		},
+	}
+
+	origin := func(_ porter.Temp) ([]byte, error) {
+		return []byte("{\"index\": {\"_id\": \"1\"}}\n{\"search_price\": 10}\n"), nil
+	}
+
+	err = s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndex(), s.Porter.Documents.MigrateDocuments(origin))
+	assert.NoError(t, err)
+
+	search := porter.SearchConfig{
+		RuntimeMappings: s.Porter.Index.Mappings.NewRuntime(
+			r.Long("search_price_doubled", r.Long.WithScript(porter.ScriptConfig{
+				Source: "emit(doc['search_price'].value * params.factor)",
+				Params: map[string]interface{}{"factor": 2},
+			})),
+		),
+		Query:  map[string]interface{}{"match_all": map[string]interface{}{}},
+		Fields: []string{"search_price_doubled"},
+	}
+
+	var result porter.SearchResult
+
+	// Bulk requests are not refreshed, so the document becomes searchable after the next refresh.
+	assert.Eventually(t, func() bool {
+		result, err = s.Porter.Search(context.Background(), c, search)
+
+		return err == nil && result.Total == 1
+	}, 10*time.Second, 200*time.Millisecond)
+
+	if assert.Len(t, result.Hits, 1) {
+		assert.Equal(t, "1", result.Hits[0].ID)
+		assert.Equal(t, []interface{}{float64(20)}, result.Hits[0].Fields["search_price_doubled"])
+	}
+
+	search.Query = map[string]interface{}{"range": map[string]interface{}{"search_price_doubled": map[string]interface{}{"gt": 20}}}
+
+	result, err = s.Porter.Search(context.Background(), c, search)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Total)
+}
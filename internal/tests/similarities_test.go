@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
+	"github.com/xoticdsign/porter2/internal/tests/suite"
+)
+
+func TestNewSimilarity_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	sim := s.Porter.Index.Settings.Similarity
+
+	script := porter.ScriptConfig{Source: "return query.boost * doc.freq;"}
+
+	cases := []struct {
+		name     string
+		in       porter.SimilarityFunc
+		expected map[string]interface{}
+	}{
+		{
+			name: "bm25 case",
+			in:   sim.BM25("bm25", sim.BM25.WithK1(1.6), sim.BM25.WithB(0.5), sim.BM25.WithDiscountOverlaps(false)),
+			expected: map[string]interface{}{
+				"bm25": map[string]interface{}{
+					"type":              "BM25",
+					"k1":                1.6,
+					"b":                 0.5,
+					"discount_overlaps": false,
+				},
+			},
+		},
+		{
+			name: "dfr case",
+			in: sim.DFR("dfr", porter.SimilarityDFRBasicModelG, porter.SimilarityDFRAfterEffectL, porter.SimilarityNormalization{
+				Type:  porter.SimilarityNormalizationH2,
+				Value: 3,
+			}),
+			expected: map[string]interface{}{
+				"dfr": map[string]interface{}{
+					"type":               "DFR",
+					"basic_model":        porter.SimilarityDFRBasicModelG,
+					"after_effect":       porter.SimilarityDFRAfterEffectL,
+					"normalization":      porter.SimilarityNormalizationH2,
+					"normalization.h2.c": 3.0,
+				},
+			},
+		},
+		{
+			name: "dfi case",
+			in:   sim.DFI("dfi", porter.SimilarityDFIIndependenceMeasureChiSquared),
+			expected: map[string]interface{}{
+				"dfi": map[string]interface{}{
+					"type":                 "DFI",
+					"independence_measure": porter.SimilarityDFIIndependenceMeasureChiSquared,
+				},
+			},
+		},
+		{
+			name: "ib case",
+			in: sim.IB("ib", porter.SimilarityIBDistributionLL, porter.SimilarityIBLambdaDF, porter.SimilarityNormalization{
+				Type: porter.SimilarityNormalizationZ,
+			}),
+			expected: map[string]interface{}{
+				"ib": map[string]interface{}{
+					"type":          "IB",
+					"distribution":  porter.SimilarityIBDistributionLL,
+					"lambda":        porter.SimilarityIBLambdaDF,
+					"normalization": porter.SimilarityNormalizationZ,
+				},
+			},
+		},
+		{
+			name: "lm dirichlet case",
+			in:   sim.LMDirichlet("lmd", sim.LMDirichlet.WithMu(1000)),
+			expected: map[string]interface{}{
+				"lmd": map[string]interface{}{
+					"type": "LMDirichlet",
+					"mu":   1000.0,
+				},
+			},
+		},
+		{
+			name: "lm jelinek mercer case",
+			in:   sim.LMJelinekMercer("lmj", sim.LMJelinekMercer.WithLambda(0.7)),
+			expected: map[string]interface{}{
+				"lmj": map[string]interface{}{
+					"type":   "LMJelinekMercer",
+					"lambda": 0.7,
+				},
+			},
+		},
+		{
+			name: "scripted case",
+			in:   sim.Scripted("scripted", script, sim.Scripted.WithWeightScript(script)),
+			expected: map[string]interface{}{
+				"scripted": map[string]interface{}{
+					"type":          "scripted",
+					"script":        script,
+					"weight_script": script,
+				},
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		s.T.Run(cs.name, func(t *testing.T) {
+			r := s.Porter.Index.Settings.NewSimilarity(cs.in)
+
+			assert.Equal(t, cs.expected, r)
+		})
+	}
+}
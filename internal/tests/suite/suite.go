@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/stretchr/testify/assert"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 
@@ -41,6 +42,54 @@ func (m mockClient) DeleteDocuments(ctx context.Context, name string, query stri
 	return nil
 }
 
+func (m mockClient) Search(ctx context.Context, name string, body []byte) (porter.SearchResult, error) {
+	return porter.SearchResult{}, nil
+}
+
+func (m mockClient) PutSynonymsSet(ctx context.Context, id string, body []byte) error {
+	return nil
+}
+
+func (m mockClient) DeleteSynonymsSet(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m mockClient) ReloadSearchAnalyzers(ctx context.Context, name string) error {
+	return nil
+}
+
+func (m mockClient) Analyze(ctx context.Context, name string, body []byte) ([]porter.Token, error) {
+	return []porter.Token{}, nil
+}
+
+func (m mockClient) CatPlugins(ctx context.Context) ([]porter.Plugin, error) {
+	return []porter.Plugin{}, nil
+}
+
+// AssertTokens() runs text through an analyzer of the index and asserts the produced token stream (terms, positions and offsets).
+func (s *suite) AssertTokens(t *testing.T, index string, analyzer string, text string, expected []porter.Token) {
+	t.Helper()
+
+	tokens, err := s.Porter.Analyze(context.Background(), index, analyzer, text)
+	if err != nil {
+		t.Fatalf("analyzing %q with [%s]: %v", text, analyzer, err)
+	}
+
+	assert.Equal(t, expected, tokens, "token stream of %q analyzed with [%s]", text, analyzer)
+}
+
+// AssertEmulatedTokens() runs text through an analyzer of the index definition with the offline emulator and asserts the produced token stream.
+func (s *suite) AssertEmulatedTokens(t *testing.T, config porter.Config, analyzer string, text string, expected []porter.Token) {
+	t.Helper()
+
+	tokens, err := s.Porter.Emulate(config, analyzer, text)
+	if err != nil {
+		t.Fatalf("emulating %q with [%s]: %v", text, analyzer, err)
+	}
+
+	assert.Equal(t, expected, tokens, "emulated token stream of %q analyzed with [%s]", text, analyzer)
+}
+
 func New(t *testing.T, offline bool) (*suite, error) {
 	t.Helper()
 	t.Parallel()
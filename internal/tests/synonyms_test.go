@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
+	"github.com/xoticdsign/porter2/internal/tests/suite"
+)
+
+func TestNewSynonymsSet_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+
+	set := a.NewSynonymsSet("merchandising",
+		a.Synonyms.Equivalent("tv", "tv", "television"),
+		a.Synonyms.Explicit("laptop", []string{"laptop", "lappy"}, []string{"notebook"}),
+	)
+
+	assert.Equal(t, porter.SynonymsSetConfig{
+		ID: "merchandising",
+		Rules: []porter.SynonymsRuleConfig{
+			{ID: "tv", Synonyms: "tv, television"},
+			{ID: "laptop", Synonyms: "laptop, lappy => notebook"},
+		},
+	}, set)
+
+	body, err := json.Marshal(set)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"synonyms_set": [{"id": "tv", "synonyms": "tv, television"}, {"id": "laptop", "synonyms": "laptop, lappy => notebook"}]}`, string(body))
+}
+
+func TestUpdateableSynonyms_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+	p := s.Porter.Index.Mappings.Properties
+
+	set := a.NewSynonymsSet("merchandising", a.Synonyms.Equivalent("tv", "tv", "television"))
+
+	analysis := &porter.AnalysisConfig{
+		Filter: a.NewFilter(
+			a.Filter.SynonymGraph("merchandising_synonyms",
+				a.Filter.SynonymGraph.WithSynonymsSet("merchandising"),
+				a.Filter.SynonymGraph.WithUpdateable(true),
+			),
+		),
+		Analyzer: a.NewAnalyzer(a.Analyzer.Custom("merchandising_search",
+			a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizerStandard),
+			a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{porter.AnalyzerCustomFilterLowercase, "merchandising_synonyms"}),
+		)),
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"merchandising_synonyms": map[string]interface{}{
+			"type":         "synonym_graph",
+			"synonyms_set": "merchandising",
+			"updateable":   true,
+		},
+	}, analysis.Filter)
+
+	cases := []struct {
+		name        string
+		in          porter.FieldFunc
+		expectedErr string
+	}{
+		{
+			name: "search analyzer case",
+			in: p.Text("synonyms_title", porter.FakeJobTitle,
+				p.Text.WithAnalyzer("standard"),
+				p.Text.WithSearchAnalyzer("merchandising_search"),
+			),
+		},
+		{
+			name:        "index analyzer case",
+			in:          p.Text("synonyms_title", porter.FakeJobTitle, p.Text.WithAnalyzer("merchandising_search")),
+			expectedErr: "field [synonyms_title]: analyzer [merchandising_search] contains updateable filters [merchandising_synonyms] and can only be used as a search_analyzer",
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			c := porter.Config{
+				Name: "porter_synonyms",
+				Definition: porter.DefinitionConfig{
+					Settings: &porter.SettingsConfig{
+						Analysis: analysis,
+					},
+					Mappings: &porter.MappingsConfig{
+						Properties: s.Porter.Index.Mappings.NewFields(cs.in),
+					},
+				},
+			}
+
+			err := s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndexWithSynonyms(set), s.Porter.Documents.NoDocuments())
+
+			if cs.expectedErr != "" {
+				assert.ErrorContains(t, err, cs.expectedErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			err = s.Porter.UpdateSynonyms(context.Background(), c, set)
+			assert.NoError(t, err)
+
+			err = s.Porter.MigrateDown(c, s.Porter.Documents.NoDocuments(), s.Porter.Index.MigrateIndexWithSynonyms(set))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSynonyms_Integration(t *testing.T) {
+	s, err := suite.New(t, false)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+	p := s.Porter.Index.Mappings.Properties
+
+	c := porter.Config{
+		Name: "porter_synonyms",
+		Definition: porter.DefinitionConfig{
+			Settings: &porter.SettingsConfig{
+				Analysis: &porter.AnalysisConfig{
+					Filter: a.NewFilter(
+						a.Filter.SynonymGraph("catalog_synonyms",
+							a.Filter.SynonymGraph.WithSynonymsSet("catalog"),
+							a.Filter.SynonymGraph.WithUpdateable(true),
+						),
+					),
+					Analyzer: a.NewAnalyzer(a.Analyzer.Custom("catalog_search",
+						a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizerStandard),
+						a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{porter.AnalyzerCustomFilterLowercase, "catalog_synonyms"}),
+					)),
+				},
+			},
+			Mappings: &porter.MappingsConfig{
+				Properties: s.Porter.Index.Mappings.NewFields(
+					p.Text("catalog_title", porter.FakeJobTitle,
+						p.Text.WithAnalyzer("standard"),
+						p.Text.WithSearchAnalyzer("catalog_search"),
+					),
+				),
+			},
+		},
+	}
+
+	set := a.NewSynonymsSet("catalog", a.Synonyms.Explicit("lappy", []string{"lappy"}, []string{"notebook"}))
+
+	err = s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndexWithSynonyms(set), s.Porter.Documents.NoDocuments())
+	assert.NoError(t, err)
+
+	s.AssertTokens(t, c.Name, "catalog_search", "Lappy", []porter.Token{
+		{Term: "notebook", StartOffset: 0, EndOffset: 5, Type: "SYNONYM", Position: 0},
+	})
+
+	set = a.NewSynonymsSet("catalog", a.Synonyms.Explicit("lappy", []string{"lappy"}, []string{"laptop"}))
+
+	err = s.Porter.UpdateSynonyms(context.Background(), c, set)
+	assert.NoError(t, err)
+
+	s.AssertTokens(t, c.Name, "catalog_search", "Lappy", []porter.Token{
+		{Term: "laptop", StartOffset: 0, EndOffset: 5, Type: "SYNONYM", Position: 0},
+	})
+
+	err = s.Porter.MigrateDown(c, s.Porter.Documents.NoDocuments(), s.Porter.Index.MigrateIndexWithSynonyms(set))
+	assert.NoError(t, err)
+}
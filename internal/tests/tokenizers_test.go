@@ -0,0 +1,188 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
+	"github.com/xoticdsign/porter2/internal/tests/suite"
+)
+
+func TestNewTokenizer_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	tk := s.Porter.Index.Settings.Analysis.Tokenizer
+
+	cases := []struct {
+		name     string
+		in       porter.TokenizerFunc
+		expected map[string]interface{}
+	}{
+		{
+			name: "edge ngram case",
+			in: tk.EdgeNGram("autocomplete",
+				tk.EdgeNGram.WithMinGram(2),
+				tk.EdgeNGram.WithMaxGram(10),
+				tk.EdgeNGram.WithTokenChars([]porter.TokenizerTokenChars{porter.TokenizerTokenCharsLetter, porter.TokenizerTokenCharsDigit}),
+			),
+			expected: map[string]interface{}{
+				"autocomplete": map[string]interface{}{
+					"type":        "edge_ngram",
+					"min_gram":    2,
+					"max_gram":    10,
+					"token_chars": []porter.TokenizerTokenChars{porter.TokenizerTokenCharsLetter, porter.TokenizerTokenCharsDigit},
+				},
+			},
+		},
+		{
+			name: "ngram custom chars case",
+			in: tk.NGram("trigram",
+				tk.NGram.WithMinGram(3),
+				tk.NGram.WithMaxGram(3),
+				tk.NGram.WithTokenChars([]porter.TokenizerTokenChars{porter.TokenizerTokenCharsCustom}),
+				tk.NGram.WithCustomTokenChars("+-_"),
+			),
+			expected: map[string]interface{}{
+				"trigram": map[string]interface{}{
+					"type":               "ngram",
+					"min_gram":           3,
+					"max_gram":           3,
+					"token_chars":        []porter.TokenizerTokenChars{porter.TokenizerTokenCharsCustom},
+					"custom_token_chars": "+-_",
+				},
+			},
+		},
+		{
+			name: "pattern case",
+			in: tk.Pattern("quoted",
+				tk.Pattern.WithPattern(`"((?:\\"|[^"]|\\")+)"`),
+				tk.Pattern.WithFlags([]porter.AnalyzerPatternFlags{porter.AnalyzerPatternFlagsCaseInsensitive, porter.AnalyzerPatternFlagsMultiline}),
+				tk.Pattern.WithGroup(1),
+			),
+			expected: map[string]interface{}{
+				"quoted": map[string]interface{}{
+					"type":    "pattern",
+					"pattern": porter.AnalyzerPatternPattern(`"((?:\\"|[^"]|\\")+)"`),
+					"flags":   "CASE_INSENSITIVE|MULTILINE",
+					"group":   1,
+				},
+			},
+		},
+		{
+			name: "char group case",
+			in:   tk.CharGroup("split", []string{"whitespace", "-", "\n"}, tk.CharGroup.WithMaxTokenLength(20)),
+			expected: map[string]interface{}{
+				"split": map[string]interface{}{
+					"type":              "char_group",
+					"tokenize_on_chars": []string{"whitespace", "-", "\n"},
+					"max_token_length":  20,
+				},
+			},
+		},
+		{
+			name: "path hierarchy case",
+			in: tk.PathHierarchy("domain",
+				tk.PathHierarchy.WithDelimiter("."),
+				tk.PathHierarchy.WithReplacement("/"),
+				tk.PathHierarchy.WithReverse(true),
+				tk.PathHierarchy.WithSkip(1),
+			),
+			expected: map[string]interface{}{
+				"domain": map[string]interface{}{
+					"type":        "path_hierarchy",
+					"delimiter":   ".",
+					"replacement": "/",
+					"reverse":     true,
+					"skip":        1,
+				},
+			},
+		},
+		{
+			name: "classic case",
+			in:   tk.Classic("classic_short", tk.Classic.WithMaxTokenLength(5)),
+			expected: map[string]interface{}{
+				"classic_short": map[string]interface{}{
+					"type":             "classic",
+					"max_token_length": 5,
+				},
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		s.T.Run(cs.name, func(t *testing.T) {
+			r := s.Porter.Index.Settings.Analysis.NewTokenizer(cs.in)
+
+			assert.Equal(t, cs.expected, r)
+		})
+	}
+}
+
+func TestValidateTokenizers_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+
+	cases := []struct {
+		name         string
+		maxNGramDiff int
+		in           porter.TokenizerFunc
+		expectedErr  string
+	}{
+		{
+			name: "happy case",
+			in:   a.Tokenizer.EdgeNGram("autocomplete", a.Tokenizer.EdgeNGram.WithMinGram(2), a.Tokenizer.EdgeNGram.WithMaxGram(10)),
+		},
+		{
+			name:         "ngram within max_ngram_diff case",
+			maxNGramDiff: 2,
+			in:           a.Tokenizer.NGram("autocomplete", a.Tokenizer.NGram.WithMinGram(2), a.Tokenizer.NGram.WithMaxGram(4)),
+		},
+		{
+			name:        "ngram over max_ngram_diff case",
+			in:          a.Tokenizer.NGram("grams", a.Tokenizer.NGram.WithMinGram(2), a.Tokenizer.NGram.WithMaxGram(4)),
+			expectedErr: "tokenizer [grams]: the difference between max_gram and min_gram [2] must be less than or equal to max_ngram_diff [1]",
+		},
+		{
+			name:        "min over max case",
+			in:          a.Tokenizer.EdgeNGram("autocomplete", a.Tokenizer.EdgeNGram.WithMinGram(5)),
+			expectedErr: "tokenizer [autocomplete]: min_gram [5] must not be greater than max_gram [2]",
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			c := porter.Config{
+				Name: "porter_tokenizers",
+				Definition: porter.DefinitionConfig{
+					Settings: &porter.SettingsConfig{
+						MaxNGramDiff: cs.maxNGramDiff,
+						Analysis: &porter.AnalysisConfig{
+							Tokenizer: a.NewTokenizer(cs.in),
+							Analyzer: a.NewAnalyzer(a.Analyzer.Custom("analyzer",
+								a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizer("autocomplete")),
+							)),
+						},
+					},
+				},
+			}
+
+			err := s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndex(), s.Porter.Documents.NoDocuments())
+
+			if cs.expectedErr == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorContains(t, err, cs.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	porter "github.com/xoticdsign/porter2"
+	"github.com/xoticdsign/porter2/internal/tests/suite"
+)
+
+func TestValidateTextOptions_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	cases := []struct {
+		name        string
+		in          porter.FieldFunc
+		expectedErr []string
+	}{
+		{
+			name: "happy case",
+			in: p.Text("validate_body", porter.FakeParagraph,
+				p.Text.WithAnalyzer("standard"),
+				p.Text.WithSearchAnalyzer("standard"),
+				p.Text.WithSearchQuoteAnalyzer("standard"),
+				p.Text.WithIndexOptions(porter.FieldIndexOptionsOffsets),
+				p.Text.WithIndexPhrases(true),
+				p.Text.WithIndexPrefixes(2, 5),
+				p.Text.WithFieldData(true),
+				p.Text.WithFieldDataFrequencyFilter(porter.FieldTextFrequencyFilter{Min: 0.001, Max: 0.1, MinSegmentSize: 500}),
+			),
+		},
+		{
+			name: "phrases without positions",
+			in: p.Text("validate_phrases", porter.FakeParagraph,
+				p.Text.WithIndexOptions(porter.FieldIndexOptionsFreqs),
+				p.Text.WithIndexPhrases(true),
+			),
+			expectedErr: []string{"field [validate_phrases]: index_phrases requires index_options to include positions"},
+		},
+		{
+			name: "bad prefixes on unindexed field",
+			in: p.Text("validate_prefixes", porter.FakeParagraph,
+				p.Text.WithIndex(false),
+				p.Text.WithIndexPrefixes(10, 25),
+			),
+			expectedErr: []string{
+				"field [validate_prefixes]: index_prefixes max_chars [25] must be less than 20",
+				"field [validate_prefixes]: index_prefixes cannot be set on an unindexed field",
+			},
+		},
+		{
+			name: "prefixes max_chars at the limit",
+			in: p.Text("validate_prefixes_limit", porter.FakeParagraph,
+				p.Text.WithIndexPrefixes(2, 20),
+			),
+			expectedErr: []string{"field [validate_prefixes_limit]: index_prefixes max_chars [20] must be less than 20"},
+		},
+		{
+			name: "quote analyzer in multi-field",
+			in: p.Text("validate_title", porter.FakeParagraph,
+				p.Text.WithFields(
+					p.Text("quoted", porter.FakeParagraph, p.Text.WithSearchQuoteAnalyzer("standard")),
+				),
+			),
+			expectedErr: []string{"field [validate_title.quoted]: search_analyzer must be set when search_quote_analyzer is set"},
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			c := porter.Config{
+				Name: "porter_validate",
+				Definition: porter.DefinitionConfig{
+					Mappings: &porter.MappingsConfig{
+						Properties: s.Porter.Index.Mappings.NewFields(cs.in),
+					},
+				},
+			}
+
+			err := s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndex(), s.Porter.Documents.NoDocuments())
+
+			if len(cs.expectedErr) == 0 {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, porter.ErrPorterMigratingUp)
+			for _, e := range cs.expectedErr {
+				assert.ErrorContains(t, err, e)
+			}
+		})
+	}
+}
+
+func TestValidateCopyToAndMeta_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	p := s.Porter.Index.Mappings.Properties
+
+	f := s.Porter.Index.Mappings.NewFields(
+		p.Long("copy_latency", porter.FakeLongInt,
+			p.Long.WithMeta(porter.FieldMeta{Unit: "ms", MetricType: porter.FieldTimeSeriesMetricGauge, Custom: map[string]string{"team": "search"}}),
+		),
+	)
+
+	assert.Equal(t, map[string]string{
+		"unit":        "ms",
+		"metric_type": "gauge",
+		"team":        "search",
+	}, f["copy_latency"].(map[string]interface{})["meta"])
+
+	cases := []struct {
+		name        string
+		in          []porter.FieldFunc
+		expectedErr []string
+	}{
+		{
+			name: "happy case",
+			in: []porter.FieldFunc{
+				p.Text("copy_all_text", porter.FakeParagraph),
+				p.Keyword("copy_title", porter.FakeJobTitle, p.Keyword.WithCopyTo("copy_all_text")),
+				p.Object("copy_author", p.Object.WithProperties(
+					p.Text("name", porter.FakeFirstName, p.Text.WithCopyTo("copy_all_text", "copy_author.bio")),
+					p.Text("bio", porter.FakeParagraph),
+				)),
+			},
+		},
+		{
+			name: "missing target case",
+			in: []porter.FieldFunc{
+				p.Keyword("copy_city", porter.FakeCity, p.Keyword.WithCopyTo("copy_missing")),
+			},
+			expectedErr: []string{"field [copy_city]: copy_to target [copy_missing] is not defined in the mappings"},
+		},
+		{
+			name: "multi-field case",
+			in: []porter.FieldFunc{
+				p.Text("copy_sink", porter.FakeParagraph),
+				p.Text("copy_body", porter.FakeParagraph, p.Text.WithFields(
+					p.Keyword("raw", porter.FakeParagraph, p.Keyword.WithCopyTo("copy_sink")),
+				)),
+			},
+			expectedErr: []string{"field [copy_body.raw]: copy_to cannot be set on a multi-field"},
+		},
+		{
+			name: "meta limits case",
+			in: []porter.FieldFunc{
+				p.Double("copy_price", porter.FakeDoubleFloat, p.Double.WithMeta(porter.FieldMeta{
+					Unit: "eur",
+					Custom: map[string]string{
+						"a": "1", "b": "2", "c": "3", "d": "4",
+						"a_very_long_meta_key_name": "5",
+					},
+				})),
+			},
+			expectedErr: []string{
+				"field [copy_price]: meta can have at most 5 entries, got [6]",
+				"field [copy_price]: meta key [a_very_long_meta_key_name] must be at most 20 characters",
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			c := porter.Config{
+				Name: "porter_validate",
+				Definition: porter.DefinitionConfig{
+					Mappings: &porter.MappingsConfig{
+						Properties: s.Porter.Index.Mappings.NewFields(cs.in...),
+					},
+				},
+			}
+
+			err := s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndex(), s.Porter.Documents.NoDocuments())
+
+			if len(cs.expectedErr) == 0 {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, porter.ErrPorterMigratingUp)
+			for _, e := range cs.expectedErr {
+				assert.ErrorContains(t, err, e)
+			}
+		})
+	}
+}
+
+func TestValidateReferences_Functional(t *testing.T) {
+	s, err := suite.New(t, true)
+	if err != nil {
+		panic(err)
+	}
+
+	a := s.Porter.Index.Settings.Analysis
+	p := s.Porter.Index.Mappings.Properties
+
+	c := porter.Config{
+		Name: "porter_validate",
+		Definition: porter.DefinitionConfig{
+			Settings: &porter.SettingsConfig{
+				Analysis: &porter.AnalysisConfig{
+					Filter: a.NewFilter(
+						a.Filter.PatternReplace("sku_punctuation", "[^A-Za-z0-9]"),
+						a.Filter.Stemmer("light_english", porter.FilterStemmerLightEnglish),
+					),
+					CharFilter: a.NewCharFilter(
+						a.CharFilter.HTMLStrip("sku_tags"),
+						a.CharFilter.Mapping("sku_dashes", a.CharFilter.Mapping.WithMappings([]string{"- => _"})),
+					),
+					Analyzer: a.NewAnalyzer(
+						a.Analyzer.Custom("products",
+							a.Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizer("autocomplete")),
+							a.Analyzer.Custom.WithFilter([]porter.AnalyzerCustomFilter{porter.AnalyzerCustomFilterLowercase, "light_english", "missing_synonyms", porter.AnalyzerCustomFilterSynonym}),
+							a.Analyzer.Custom.WithCharFilter([]porter.AnalyzerCustomCharFilter{porter.AnalyzerCustomCharFilterHTMLStrip, porter.AnalyzerCustomCharFilterMapping}),
+						),
+					),
+					Normalizer: a.NewNormalizer(
+						a.Normalizer.Custom("sku",
+							a.Normalizer.Custom.WithFilter([]porter.NormalizerCustomFilter{porter.NormalizerCustomFilterLowercase, "sku_punctuation", "light_english", "stemmer", "pattern_replace"}),
+							a.Normalizer.Custom.WithCharFilter([]porter.NormalizerCustomCharFilter{porter.NormalizerCustomCharFilterHTMLStrip, "sku_tags", "sku_dashes"}),
+						),
+					),
+				},
+			},
+			Mappings: &porter.MappingsConfig{
+				Properties: s.Porter.Index.Mappings.NewFields(
+					p.Text("ref_title", porter.FakeJobTitle,
+						p.Text.WithAnalyzer("products"),
+						p.Text.WithSearchAnalyzer("missing_search"),
+						p.Text.WithFields(p.Text("english", porter.FakeJobTitle, p.Text.WithAnalyzer("english"))),
+					),
+					p.Keyword("ref_sku", porter.FakeUUID, p.Keyword.WithNormalizer("sku")),
+					p.Keyword("ref_code", porter.FakeUUID, p.Keyword.WithNormalizer("missing_normalizer"), p.Keyword.WithSimilarity("missing_similarity")),
+				),
+			},
+		},
+	}
+
+	r := s.Porter.Validate(c)
+
+	assert.False(t, r.Valid())
+	assert.Equal(t, []porter.ValidationProblem{
+		{Component: "analyzer", Name: "products", Message: "tokenizer [autocomplete] is not defined in the analysis settings"},
+		{Component: "analyzer", Name: "products", Message: "filter [missing_synonyms] is not defined in the analysis settings"},
+		{Component: "analyzer", Name: "products", Message: "filter [synonym] requires parameters and must be defined in the analysis settings"},
+		{Component: "analyzer", Name: "products", Message: "char_filter [mapping] requires parameters and must be defined in the analysis settings"},
+		{Component: "normalizer", Name: "sku", Message: "filter [light_english] of type [stemmer] cannot be used in a normalizer"},
+		{Component: "normalizer", Name: "sku", Message: "filter [stemmer] cannot be used in a normalizer"},
+		{Component: "normalizer", Name: "sku", Message: "filter [pattern_replace] requires parameters and must be defined in the analysis settings"},
+		{Component: "normalizer", Name: "sku", Message: "char_filter [html_strip] cannot be used in a normalizer"},
+		{Component: "normalizer", Name: "sku", Message: "char_filter [sku_tags] of type [html_strip] cannot be used in a normalizer"},
+		{Component: "field", Name: "ref_code", Message: "normalizer [missing_normalizer] is not defined in the analysis settings"},
+		{Component: "field", Name: "ref_code", Message: "similarity [missing_similarity] is not defined in the index settings"},
+		{Component: "field", Name: "ref_title", Message: "search_analyzer [missing_search] is not defined in the analysis settings"},
+	}, r.Problems)
+
+	err = s.Porter.MigrateUp(c, s.Porter.Index.MigrateIndex(), s.Porter.Documents.NoDocuments())
+
+	assert.ErrorIs(t, err, porter.ErrPorterMigratingUp)
+	assert.ErrorContains(t, err, "field [ref_title]: search_analyzer [missing_search] is not defined in the analysis settings")
+	assert.ErrorContains(t, err, "normalizer [sku]: filter [light_english] of type [stemmer] cannot be used in a normalizer")
+	assert.ErrorContains(t, err, "normalizer [sku]: filter [stemmer] cannot be used in a normalizer")
+}
@@ -0,0 +1,439 @@
+package porter
+
+import (
+	"slices"
+	"strings"
+)
+
+/*
+
+This file rebuilds the built-in language analyzers as custom analyzers. A language analyzer only exposes
+its stopwords and stem exclusion; rebuilding it produces the documented custom equivalent (tokenizer,
+elision/possessive filters, stop, keyword_marker and stemmer) so that individual filters can be replaced,
+removed or extended.
+
+A rebuilt analyzer spans several sections of AnalysisConfig{}, so it is placed with Analyzer(), Filter()
+and CharFilter():
+
+	english := a.Analyzer.Language.Rebuild("english_folded", porter.AnalyzerLanguageEnglish).
+		WithFilterAfter(porter.AnalyzerCustomFilterLowercase, porter.AnalyzerCustomFilterASCIIFolding)
+
+	Analysis: &porter.AnalysisConfig{
+		Analyzer: a.NewAnalyzer(english.Analyzer()),
+		Filter:   a.NewFilter(english.Filter()),
+	}
+
+The generated filters are named after the analyzer (e.g., english_folded_stop, english_folded_stemmer),
+which is the name WithFilterDefinition() uses to replace them.
+
+*/
+
+// languageRebuild{} describes the documented custom equivalent of a language analyzer.
+type languageRebuild struct {
+	tokenizer AnalyzerCustomTokenizer
+	stemmer   FilterStemmerLanguage
+	// filter is the filter chain; the <language>_stop, <language>_keywords and <language>_stemmer filters are defined from the analyzer properties. Generated filters are renamed after the analyzer on rebuild.
+	filter      []string
+	filters     map[string]interface{}
+	charFilter  []string
+	charFilters map[string]interface{}
+}
+
+func standardRebuild(language string, stemmer FilterStemmerLanguage) languageRebuild {
+	return languageRebuild{
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		stemmer:   stemmer,
+		filter:    []string{"lowercase", language + "_stop", language + "_keywords", language + "_stemmer"},
+	}
+}
+
+func elisionRebuild(language string, stemmer FilterStemmerLanguage, articles []string) languageRebuild {
+	r := standardRebuild(language, stemmer)
+
+	r.filter = append([]string{language + "_elision"}, r.filter...)
+	r.filters = map[string]interface{}{
+		language + "_elision": map[string]interface{}{
+			"type":          "elision",
+			"articles":      articles,
+			"articles_case": true,
+		},
+	}
+
+	return r
+}
+
+var languageRebuilds = map[AnalyzerLanguageLanguage]languageRebuild{
+	AnalyzerLanguageArabic: {
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		stemmer:   FilterStemmerArabic,
+		filter:    []string{"lowercase", "decimal_digit", "arabic_stop", "arabic_normalization", "arabic_keywords", "arabic_stemmer"},
+	},
+	AnalyzerLanguageArmenian: standardRebuild("armenian", FilterStemmerArmenian),
+	AnalyzerLanguageBasque:   standardRebuild("basque", FilterStemmerBasque),
+	AnalyzerLanguageBengali: {
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		stemmer:   FilterStemmerBengali,
+		filter:    []string{"lowercase", "decimal_digit", "bengali_keywords", "indic_normalization", "bengali_normalization", "bengali_stop", "bengali_stemmer"},
+	},
+	AnalyzerLanguageBrazilian: standardRebuild("brazilian", FilterStemmerBrazilian),
+	AnalyzerLanguageBulgarian: standardRebuild("bulgarian", FilterStemmerBulgarian),
+	AnalyzerLanguageCatalan:   elisionRebuild("catalan", FilterStemmerCatalan, []string{"d", "l", "m", "n", "s", "t"}),
+	AnalyzerLanguageCJK: {
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		filter:    []string{"cjk_width", "lowercase", "cjk_bigram", "cjk_stop"},
+	},
+	AnalyzerLanguageCzech:  standardRebuild("czech", FilterStemmerCzech),
+	AnalyzerLanguageDanish: standardRebuild("danish", FilterStemmerDanish),
+	AnalyzerLanguageDutch: {
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		stemmer:   FilterStemmerDutch,
+		filter:    []string{"lowercase", "dutch_stop", "dutch_keywords", "dutch_override", "dutch_stemmer"},
+		filters: map[string]interface{}{
+			"dutch_override": map[string]interface{}{
+				"type":  "stemmer_override",
+				"rules": []string{"fiets=>fiets", "bromfiets=>bromfiets", "ei=>eier", "kind=>kinder"},
+			},
+		},
+	},
+	AnalyzerLanguageEnglish: {
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		stemmer:   FilterStemmerEnglish,
+		filter:    []string{"english_possessive_stemmer", "lowercase", "english_stop", "english_keywords", "english_stemmer"},
+		filters: map[string]interface{}{
+			"english_possessive_stemmer": map[string]interface{}{
+				"type":     "stemmer",
+				"language": FilterStemmerPossessiveEnglish,
+			},
+		},
+	},
+	AnalyzerLanguageEstonian: standardRebuild("estonian", FilterStemmerEstonian),
+	AnalyzerLanguageFinnish:  standardRebuild("finnish", FilterStemmerFinnish),
+	AnalyzerLanguageFrench: elisionRebuild("french", FilterStemmerLightFrench, []string{
+		"l", "m", "t", "qu", "n", "s", "j", "d", "c", "jusqu", "quoiqu", "lorsqu", "puisqu",
+	}),
+	AnalyzerLanguageGalician: standardRebuild("galician", FilterStemmerGalician),
+	AnalyzerLanguageGerman: {
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		stemmer:   FilterStemmerLightGerman,
+		filter:    []string{"lowercase", "german_stop", "german_keywords", "german_normalization", "german_stemmer"},
+	},
+	AnalyzerLanguageGreek: {
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		stemmer:   FilterStemmerGreek,
+		filter:    []string{"greek_lowercase", "greek_stop", "greek_keywords", "greek_stemmer"},
+		filters: map[string]interface{}{
+			"greek_lowercase": map[string]interface{}{
+				"type":     "lowercase",
+				"language": "greek",
+			},
+		},
+	},
+	AnalyzerLanguageHindi: {
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		stemmer:   FilterStemmerHindi,
+		filter:    []string{"lowercase", "decimal_digit", "hindi_keywords", "indic_normalization", "hindi_normalization", "hindi_stop", "hindi_stemmer"},
+	},
+	AnalyzerLanguageHungarian:  standardRebuild("hungarian", FilterStemmerHungarian),
+	AnalyzerLanguageIndonesian: standardRebuild("indonesian", FilterStemmerIndonesian),
+	AnalyzerLanguageIrish: {
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		stemmer:   FilterStemmerIrish,
+		filter:    []string{"irish_hyphenation", "irish_elision", "irish_lowercase", "irish_stop", "irish_keywords", "irish_stemmer"},
+		filters: map[string]interface{}{
+			"irish_hyphenation": map[string]interface{}{
+				"type":        "stop",
+				"stopwords":   []string{"h", "n", "t"},
+				"ignore_case": true,
+			},
+			"irish_elision": map[string]interface{}{
+				"type":          "elision",
+				"articles":      []string{"d", "m", "b"},
+				"articles_case": true,
+			},
+			"irish_lowercase": map[string]interface{}{
+				"type":     "lowercase",
+				"language": "irish",
+			},
+		},
+	},
+	AnalyzerLanguageItalian: elisionRebuild("italian", FilterStemmerLightItalian, []string{
+		"c", "l", "all", "dall", "dell", "nell", "sull", "coll", "pell", "gl", "agl", "dagl", "degl", "negl", "sugl", "un", "m", "t", "s", "v", "d",
+	}),
+	AnalyzerLanguageLatvian:    standardRebuild("latvian", FilterStemmerLatvian),
+	AnalyzerLanguageLithuanian: standardRebuild("lithuanian", FilterStemmerLithuanian),
+	AnalyzerLanguageNorwegian:  standardRebuild("norwegian", FilterStemmerNorwegian),
+	AnalyzerLanguagePersian: {
+		tokenizer:  AnalyzerCustomTokenizerStandard,
+		filter:     []string{"lowercase", "decimal_digit", "arabic_normalization", "persian_normalization", "persian_stop"},
+		charFilter: []string{"zero_width_spaces"},
+		charFilters: map[string]interface{}{
+			"zero_width_spaces": map[string]interface{}{
+				"type":     "mapping",
+				"mappings": []string{"\\u200C=>\\u0020"},
+			},
+		},
+	},
+	AnalyzerLanguagePortuguese: standardRebuild("portuguese", FilterStemmerLightPortuguese),
+	AnalyzerLanguageRomanian:   standardRebuild("romanian", FilterStemmerRomanian),
+	AnalyzerLanguageRussian:    standardRebuild("russian", FilterStemmerRussian),
+	AnalyzerLanguageSerbian: {
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		stemmer:   FilterStemmerSerbian,
+		filter:    []string{"lowercase", "serbian_stop", "serbian_keywords", "serbian_stemmer", "serbian_normalization"},
+	},
+	AnalyzerLanguageSorani: {
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		stemmer:   FilterStemmerSorani,
+		filter:    []string{"sorani_normalization", "lowercase", "decimal_digit", "sorani_stop", "sorani_keywords", "sorani_stemmer"},
+	},
+	AnalyzerLanguageSpanish: standardRebuild("spanish", FilterStemmerLightSpanish),
+	AnalyzerLanguageSwedish: standardRebuild("swedish", FilterStemmerSwedish),
+	AnalyzerLanguageTurkish: {
+		tokenizer: AnalyzerCustomTokenizerStandard,
+		stemmer:   FilterStemmerTurkish,
+		filter:    []string{"apostrophe", "turkish_lowercase", "turkish_stop", "turkish_keywords", "turkish_stemmer"},
+		filters: map[string]interface{}{
+			"turkish_lowercase": map[string]interface{}{
+				"type":     "lowercase",
+				"language": "turkish",
+			},
+		},
+	},
+	AnalyzerLanguageThai: {
+		tokenizer: AnalyzerCustomTokenizerThai,
+		filter:    []string{"lowercase", "decimal_digit", "thai_stop"},
+	},
+}
+
+// AnalyzerLanguageRebuilt{} is a language analyzer expanded into its custom equivalent. Its methods return modified copies, so a rebuild can be shared as a base.
+type AnalyzerLanguageRebuilt struct {
+	name        string
+	tokenizer   AnalyzerCustomTokenizer
+	filter      []AnalyzerCustomFilter
+	filters     map[string]interface{}
+	charFilter  []AnalyzerCustomCharFilter
+	charFilters map[string]interface{}
+}
+
+// Rebuild() expands a language analyzer into its documented custom equivalent. WithStopwords(), WithStopwordsPath() and WithStemExclusion() configure the language filters the same way they configure the built-in analyzer.
+func (l AnalyzerLanguage) Rebuild(name string, language AnalyzerLanguageLanguage, properties ...AnalyzerLanguageProperties) AnalyzerLanguageRebuilt {
+	p := map[string]interface{}{}
+
+	for _, fn := range properties {
+		if fn == nil {
+			continue
+		}
+		for k, v := range fn() {
+			p[k] = v
+		}
+	}
+
+	recipe, ok := languageRebuilds[language]
+	if !ok {
+		recipe = standardRebuild(string(language), FilterStemmerLanguage(language))
+	}
+
+	lang := string(language)
+
+	stop := map[string]interface{}{
+		"type":      "stop",
+		"stopwords": "_" + lang + "_",
+	}
+	if v, ok := p["stopwords"]; ok {
+		stop["stopwords"] = v
+	}
+	if v, ok := p["stopwords_path"]; ok {
+		delete(stop, "stopwords")
+		stop["stopwords_path"] = v
+	}
+
+	// The generated filters are named after the analyzer, so several rebuilds of a language can be placed side by side.
+	rename := func(k string) string {
+		return name + "_" + strings.TrimPrefix(k, lang+"_")
+	}
+
+	generated := map[string]interface{}{
+		lang + "_stop": stop,
+	}
+
+	for k, v := range recipe.filters {
+		generated[k] = v
+	}
+
+	if recipe.stemmer != "" {
+		generated[lang+"_stemmer"] = map[string]interface{}{
+			"type":     "stemmer",
+			"language": recipe.stemmer,
+		}
+	}
+
+	// The keyword_marker filter is only part of the chain when terms are excluded from stemming and the language stems at all.
+	keywords, hasKeywords := p["stem_exclusion"]
+	hasKeywords = hasKeywords && slices.Contains(recipe.filter, lang+"_keywords")
+
+	if hasKeywords {
+		generated[lang+"_keywords"] = map[string]interface{}{
+			"type":     "keyword_marker",
+			"keywords": keywords,
+		}
+	}
+
+	r := AnalyzerLanguageRebuilt{
+		name:        name,
+		tokenizer:   recipe.tokenizer,
+		filters:     map[string]interface{}{},
+		charFilters: map[string]interface{}{},
+	}
+
+	for k, v := range generated {
+		r.filters[rename(k)] = v
+	}
+	for k, v := range recipe.charFilters {
+		r.charFilters[rename(k)] = v
+	}
+
+	for _, f := range recipe.filter {
+		if f == lang+"_keywords" && !hasKeywords {
+			continue
+		}
+
+		_, ok := generated[f]
+		if ok {
+			f = rename(f)
+		}
+
+		r.filter = append(r.filter, AnalyzerCustomFilter(f))
+	}
+	for _, c := range recipe.charFilter {
+		r.charFilter = append(r.charFilter, AnalyzerCustomCharFilter(rename(c)))
+	}
+
+	return r
+}
+
+// WithFilterAfter() inserts filters right after the first occurrence of a filter in the chain, or at its end when the filter is not part of it.
+func (r AnalyzerLanguageRebuilt) WithFilterAfter(after AnalyzerCustomFilter, filters ...AnalyzerCustomFilter) AnalyzerLanguageRebuilt {
+	chain := []AnalyzerCustomFilter{}
+	inserted := false
+
+	for _, f := range r.filter {
+		chain = append(chain, f)
+
+		if f == after && !inserted {
+			chain = append(chain, filters...)
+			inserted = true
+		}
+	}
+
+	if !inserted {
+		chain = append(chain, filters...)
+	}
+
+	r.filter = chain
+
+	return r
+}
+
+// WithFilterBefore() inserts filters right before the first occurrence of a filter in the chain, or at its start when the filter is not part of it.
+func (r AnalyzerLanguageRebuilt) WithFilterBefore(before AnalyzerCustomFilter, filters ...AnalyzerCustomFilter) AnalyzerLanguageRebuilt {
+	chain := []AnalyzerCustomFilter{}
+	inserted := false
+
+	for _, f := range r.filter {
+		if f == before && !inserted {
+			chain = append(chain, filters...)
+			inserted = true
+		}
+
+		chain = append(chain, f)
+	}
+
+	if !inserted {
+		chain = append(append([]AnalyzerCustomFilter{}, filters...), chain...)
+	}
+
+	r.filter = chain
+
+	return r
+}
+
+// WithoutFilter() removes filters from the chain, along with their definitions.
+func (r AnalyzerLanguageRebuilt) WithoutFilter(filters ...AnalyzerCustomFilter) AnalyzerLanguageRebuilt {
+	remove := map[AnalyzerCustomFilter]bool{}
+	for _, f := range filters {
+		remove[f] = true
+	}
+
+	chain := []AnalyzerCustomFilter{}
+	for _, f := range r.filter {
+		if !remove[f] {
+			chain = append(chain, f)
+		}
+	}
+
+	definitions := map[string]interface{}{}
+	for k, v := range r.filters {
+		if !remove[AnalyzerCustomFilter(k)] {
+			definitions[k] = v
+		}
+	}
+
+	r.filter = chain
+	r.filters = definitions
+
+	return r
+}
+
+// WithFilterDefinition() adds filter definitions to the rebuild, replacing a generated filter of the same name (e.g., english_folded_stemmer).
+func (r AnalyzerLanguageRebuilt) WithFilterDefinition(filters ...FilterFunc) AnalyzerLanguageRebuilt {
+	definitions := map[string]interface{}{}
+	for k, v := range r.filters {
+		definitions[k] = v
+	}
+
+	for _, fn := range filters {
+		if fn == nil {
+			continue
+		}
+		for k, v := range fn() {
+			definitions[k] = v
+		}
+	}
+
+	r.filters = definitions
+
+	return r
+}
+
+// Analyzer() returns the custom analyzer, to be placed into AnalysisConfig.Analyzer with NewAnalyzer().
+func (r AnalyzerLanguageRebuilt) Analyzer() AnalyzerFunc {
+	return func() map[string]interface{} {
+		a := map[string]interface{}{
+			"type":      "custom",
+			"tokenizer": r.tokenizer,
+			"filter":    r.filter,
+		}
+
+		if len(r.charFilter) > 0 {
+			a["char_filter"] = r.charFilter
+		}
+
+		return map[string]interface{}{
+			r.name: a,
+		}
+	}
+}
+
+// Filter() returns the definitions of the language filters, to be placed into AnalysisConfig.Filter with NewFilter().
+func (r AnalyzerLanguageRebuilt) Filter() FilterFunc {
+	return func() map[string]interface{} {
+		return r.filters
+	}
+}
+
+// CharFilter() returns the definitions of the language character filters (only used by persian), to be placed into AnalysisConfig.CharFilter with NewCharFilter().
+func (r AnalyzerLanguageRebuilt) CharFilter() CharFilterFunc {
+	return func() map[string]interface{} {
+		return r.charFilters
+	}
+}
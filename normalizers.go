@@ -2,22 +2,32 @@ package porter
 
 /*
 
-This file includes the core definitions for building custom normalizers using a fluent and
-composable function-based API. Each normalizer is defined with a name and optional configuration
+This file includes the core definitions for building built-in and custom normalizers using a fluent
+and composable function-based API. Each normalizer is defined with a name and optional configuration
 functions (e.g., WithCharFilter(), WithFilter()), producing a map structure compatible with Elasticsearch.
 
+A normalizer produces a single token, so only filters that work character by character can be part of it.
+Custom normalizers leave out the built-in filters that cannot (e.g., stemmer, stop); filters configured in
+AnalysisConfig.Filter are referenced by name and checked against their type before the index is created.
+
 */
 
 type normalizer struct {
-	Custom NormalizerCustom
+	Lowercase NormalizerLowercase
+	Custom    NormalizerCustom
 }
 
-// NewNormalizer() takes a composed normalizer function and converts it into a map[string]interface{} structure compatible with Elasticsearch.
-func (a analysis) NewNormalizer(normalizer NormalizerFunc) map[string]interface{} {
+// NewNormalizer() takes one or more composed normalizer functions and converts them into a map[string]interface{} structure compatible with Elasticsearch.
+func (a analysis) NewNormalizer(normalizers ...NormalizerFunc) map[string]interface{} {
 	r := map[string]interface{}{}
 
-	for k, v := range normalizer() {
-		r[k] = v
+	for _, normalizer := range normalizers {
+		if normalizer == nil {
+			continue
+		}
+		for k, v := range normalizer() {
+			r[k] = v
+		}
 	}
 
 	return r
@@ -25,6 +35,27 @@ func (a analysis) NewNormalizer(normalizer NormalizerFunc) map[string]interface{
 
 type NormalizerFunc func() map[string]interface{}
 
+// LOWERCASE NORMALIZER
+
+// NormalizerBuiltinLowercase is the name of the built-in lowercase normalizer, which keyword fields can reference without defining it.
+const NormalizerBuiltinLowercase = "lowercase"
+
+type NormalizerLowercase func(name string) NormalizerFunc
+
+func newNormalizerLowercase() NormalizerLowercase {
+	return func(name string) NormalizerFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			r["type"] = "lowercase"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
 // CUSTOM NORMALIZER
 
 type NormalizerCustomProperties func() map[string]interface{}
@@ -61,7 +92,7 @@ var (
 	NormalizerCustomCharFilterPatternReplace NormalizerCustomCharFilter = "pattern_replace"
 )
 
-// WithCharFilter() defines a list of character filters for the custom normalizer.
+// WithCharFilter() defines a list of character filters for the custom normalizer. A character filter defined in AnalysisConfig.CharFilter is referenced by its name, e.g. NormalizerCustomCharFilter("sku_separators").
 func (c NormalizerCustom) WithCharFilter(value []NormalizerCustomCharFilter) NormalizerCustomProperties {
 	return func() map[string]interface{} {
 		return map[string]interface{}{
@@ -92,7 +123,7 @@ var (
 	NormalizerCustomFilterUppercase            NormalizerCustomFilter = "uppercase"
 )
 
-// WithFilter() defines a list of token filters to be used in the custom normalizer.
+// WithFilter() defines a list of token filters to be used in the custom normalizer. A filter defined in AnalysisConfig.Filter is referenced by its name, e.g. NormalizerCustomFilter("sku_punctuation"). Filters that cannot be used in a normalizer are reported by validation before the index is created.
 func (c NormalizerCustom) WithFilter(value []NormalizerCustomFilter) NormalizerCustomProperties {
 	return func() map[string]interface{} {
 		return map[string]interface{}{
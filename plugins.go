@@ -0,0 +1,688 @@
+package porter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*
+
+This file contains the factory functions and types for constructing analysis components provided by the
+official Elasticsearch plugins (analysis-icu, analysis-kuromoji, analysis-nori, analysis-smartcn and
+analysis-phonetic). The builders are placed into AnalysisConfig.Tokenizer and AnalysisConfig.Filter like
+any other configurable component; the plugin components that need no parameters are referenced by the
+constants below (e.g., AnalyzerCustomTokenizerSmartCN) or, for analyzers, by name (e.g., "kuromoji").
+
+A plugin has to be installed on the cluster before an index can use its components. MigrateIndex() finds
+the plugins the definition depends on and checks them against _cat/plugins before creating the index.
+
+*/
+
+var (
+	ErrMissingPlugins = fmt.Errorf("plugins: index definition uses analysis plugins that are not installed")
+)
+
+// Plugin{} represents a plugin installed on a node, as listed by _cat/plugins.
+type Plugin struct {
+	Node      string `json:"name"`
+	Component string `json:"component"`
+	Version   string `json:"version"`
+}
+
+var (
+	AnalyzerCustomTokenizerICU      AnalyzerCustomTokenizer = "icu_tokenizer"
+	AnalyzerCustomTokenizerKuromoji AnalyzerCustomTokenizer = "kuromoji_tokenizer"
+	AnalyzerCustomTokenizerNori     AnalyzerCustomTokenizer = "nori_tokenizer"
+	AnalyzerCustomTokenizerSmartCN  AnalyzerCustomTokenizer = "smartcn_tokenizer"
+
+	AnalyzerCustomFilterICUFolding           AnalyzerCustomFilter = "icu_folding"
+	AnalyzerCustomFilterICUNormalizer        AnalyzerCustomFilter = "icu_normalizer"
+	AnalyzerCustomFilterKuromojiBaseForm     AnalyzerCustomFilter = "kuromoji_baseform"
+	AnalyzerCustomFilterKuromojiPartOfSpeech AnalyzerCustomFilter = "kuromoji_part_of_speech"
+	AnalyzerCustomFilterKuromojiReadingForm  AnalyzerCustomFilter = "kuromoji_readingform"
+	AnalyzerCustomFilterKuromojiStemmer      AnalyzerCustomFilter = "kuromoji_stemmer"
+	AnalyzerCustomFilterJapaneseStop         AnalyzerCustomFilter = "ja_stop"
+	AnalyzerCustomFilterNoriPartOfSpeech     AnalyzerCustomFilter = "nori_part_of_speech"
+	AnalyzerCustomFilterNoriReadingForm      AnalyzerCustomFilter = "nori_readingform"
+	AnalyzerCustomFilterNoriNumber           AnalyzerCustomFilter = "nori_number"
+	AnalyzerCustomFilterSmartCNStop          AnalyzerCustomFilter = "smartcn_stop"
+
+	AnalyzerCustomCharFilterICUNormalizer         AnalyzerCustomCharFilter = "icu_normalizer"
+	AnalyzerCustomCharFilterKuromojiIterationMark AnalyzerCustomCharFilter = "kuromoji_iteration_mark"
+
+	NormalizerCustomFilterICUFolding    NormalizerCustomFilter = "icu_folding"
+	NormalizerCustomFilterICUNormalizer NormalizerCustomFilter = "icu_normalizer"
+)
+
+// The maps below tie the built-in names and types of plugin components to the plugin that provides them.
+var (
+	pluginAnalyzers = map[string]string{
+		"icu_analyzer": "analysis-icu",
+		"kuromoji":     "analysis-kuromoji",
+		"nori":         "analysis-nori",
+		"smartcn":      "analysis-smartcn",
+	}
+
+	pluginTokenizers = map[string]string{
+		"icu_tokenizer":      "analysis-icu",
+		"kuromoji_tokenizer": "analysis-kuromoji",
+		"nori_tokenizer":     "analysis-nori",
+		"smartcn_tokenizer":  "analysis-smartcn",
+	}
+
+	pluginFilters = map[string]string{
+		"icu_folding":             "analysis-icu",
+		"icu_normalizer":          "analysis-icu",
+		"icu_collation":           "analysis-icu",
+		"icu_transform":           "analysis-icu",
+		"kuromoji_baseform":       "analysis-kuromoji",
+		"kuromoji_part_of_speech": "analysis-kuromoji",
+		"kuromoji_readingform":    "analysis-kuromoji",
+		"kuromoji_stemmer":        "analysis-kuromoji",
+		"kuromoji_number":         "analysis-kuromoji",
+		"ja_stop":                 "analysis-kuromoji",
+		"nori_part_of_speech":     "analysis-nori",
+		"nori_readingform":        "analysis-nori",
+		"nori_number":             "analysis-nori",
+		"smartcn_stop":            "analysis-smartcn",
+		"phonetic":                "analysis-phonetic",
+	}
+
+	pluginCharFilters = map[string]string{
+		"icu_normalizer":          "analysis-icu",
+		"kuromoji_iteration_mark": "analysis-kuromoji",
+	}
+)
+
+// requiredPlugins() finds the plugins whose components are defined or referenced by name in the index definition.
+func requiredPlugins(d DefinitionConfig) []string {
+	plugins := map[string]bool{}
+
+	require := func(components map[string]string, name string) {
+		plugin, ok := components[name]
+		if ok {
+			plugins[plugin] = true
+		}
+	}
+
+	a := &AnalysisConfig{}
+	if d.Settings != nil && d.Settings.Analysis != nil {
+		a = d.Settings.Analysis
+	}
+
+	typeOf := func(v interface{}) string {
+		r, ok := v.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+
+		return fmt.Sprint(r["type"])
+	}
+
+	for _, v := range a.Tokenizer {
+		require(pluginTokenizers, typeOf(v))
+	}
+	for _, v := range a.Filter {
+		require(pluginFilters, typeOf(v))
+	}
+	for _, v := range a.CharFilter {
+		require(pluginCharFilters, typeOf(v))
+	}
+
+	// Components referenced by name are only provided by a plugin when they are not configured in the index.
+	references := func(r map[string]interface{}) {
+		if t, ok := r["tokenizer"]; ok {
+			if _, defined := a.Tokenizer[fmt.Sprint(t)]; !defined {
+				require(pluginTokenizers, fmt.Sprint(t))
+			}
+		}
+		for _, f := range stringsOf(r["filter"]) {
+			if _, defined := a.Filter[f]; !defined {
+				require(pluginFilters, f)
+			}
+		}
+		for _, c := range stringsOf(r["char_filter"]) {
+			if _, defined := a.CharFilter[c]; !defined {
+				require(pluginCharFilters, c)
+			}
+		}
+	}
+
+	for _, v := range a.Analyzer {
+		require(pluginAnalyzers, typeOf(v))
+
+		r, ok := v.(map[string]interface{})
+		if ok {
+			references(r)
+		}
+	}
+	for _, v := range a.Normalizer {
+		r, ok := v.(map[string]interface{})
+		if ok {
+			references(r)
+		}
+	}
+
+	var fields func(properties map[string]interface{})
+	fields = func(properties map[string]interface{}) {
+		for _, v := range properties {
+			r, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, key := range []string{"analyzer", "search_analyzer", "search_quote_analyzer"} {
+				analyzer, ok := r[key]
+				if !ok {
+					continue
+				}
+				if _, defined := a.Analyzer[fmt.Sprint(analyzer)]; !defined {
+					require(pluginAnalyzers, fmt.Sprint(analyzer))
+				}
+			}
+
+			if children, ok := r["properties"].(map[string]interface{}); ok {
+				fields(children)
+			}
+			if subFields, ok := r["fields"].(map[string]interface{}); ok {
+				fields(subFields)
+			}
+		}
+	}
+
+	if d.Mappings != nil {
+		fields(d.Mappings.Properties)
+	}
+
+	r := make([]string, 0, len(plugins))
+	for p := range plugins {
+		r = append(r, p)
+	}
+	sort.Strings(r)
+
+	return r
+}
+
+// checkPlugins() verifies that the plugins required by the index definition are installed. The cluster is only asked when the definition uses plugin components.
+func checkPlugins(ctx context.Context, client searcher, d DefinitionConfig) error {
+	required := requiredPlugins(d)
+	if len(required) == 0 {
+		return nil
+	}
+
+	installed, err := client.CatPlugins(ctx)
+	if err != nil {
+		return err
+	}
+
+	components := map[string]bool{}
+	for _, p := range installed {
+		components[p.Component] = true
+	}
+
+	var missing []string
+	for _, p := range required {
+		if !components[p] {
+			missing = append(missing, p)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w [%s]", ErrMissingPlugins, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// ICU TOKENIZER
+
+type TokenizerICUProperties func() map[string]interface{}
+type TokenizerICU func(name string, properties ...TokenizerICUProperties) TokenizerFunc
+
+func newTokenizerICU() TokenizerICU {
+	return func(name string, properties ...TokenizerICUProperties) TokenizerFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "icu_tokenizer"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithRuleFiles() sets per-script RBBI rule files, e.g. "Latn:KeywordTokenizer.rbbi".
+func (i TokenizerICU) WithRuleFiles(value string) TokenizerICUProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"rule_files": value,
+		}
+	}
+}
+
+// KUROMOJI TOKENIZER
+
+// TokenizerKuromojiMode defines how the kuromoji tokenizer handles compound and unknown words.
+type TokenizerKuromojiMode string
+
+var (
+	TokenizerKuromojiModeNormal   TokenizerKuromojiMode = "normal"
+	TokenizerKuromojiModeSearch   TokenizerKuromojiMode = "search"
+	TokenizerKuromojiModeExtended TokenizerKuromojiMode = "extended"
+)
+
+type TokenizerKuromojiProperties func() map[string]interface{}
+type TokenizerKuromoji func(name string, properties ...TokenizerKuromojiProperties) TokenizerFunc
+
+func newTokenizerKuromoji() TokenizerKuromoji {
+	return func(name string, properties ...TokenizerKuromojiProperties) TokenizerFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "kuromoji_tokenizer"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithMode() sets the tokenization mode.
+func (k TokenizerKuromoji) WithMode(value TokenizerKuromojiMode) TokenizerKuromojiProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"mode": value,
+		}
+	}
+}
+
+// WithDiscardPunctuation() sets whether punctuation is discarded from the output.
+func (k TokenizerKuromoji) WithDiscardPunctuation(enabled bool) TokenizerKuromojiProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"discard_punctuation": enabled,
+		}
+	}
+}
+
+// WithUserDictionary() sets the path, relative to the config directory, of a CSV user dictionary.
+func (k TokenizerKuromoji) WithUserDictionary(value string) TokenizerKuromojiProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"user_dictionary": value,
+		}
+	}
+}
+
+// WithUserDictionaryRules() sets the user dictionary inline, one CSV entry per rule (e.g., "東京スカイツリー,東京 スカイツリー,トウキョウ スカイツリー,カスタム名詞").
+func (k TokenizerKuromoji) WithUserDictionaryRules(value []string) TokenizerKuromojiProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"user_dictionary_rules": value,
+		}
+	}
+}
+
+// WithDiscardCompoundToken() sets whether the original compound tokens are discarded in search mode.
+func (k TokenizerKuromoji) WithDiscardCompoundToken(enabled bool) TokenizerKuromojiProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"discard_compound_token": enabled,
+		}
+	}
+}
+
+// NORI TOKENIZER
+
+// TokenizerNoriDecompoundMode defines how the nori tokenizer handles compound tokens.
+type TokenizerNoriDecompoundMode string
+
+var (
+	TokenizerNoriDecompoundModeNone    TokenizerNoriDecompoundMode = "none"
+	TokenizerNoriDecompoundModeDiscard TokenizerNoriDecompoundMode = "discard"
+	TokenizerNoriDecompoundModeMixed   TokenizerNoriDecompoundMode = "mixed"
+)
+
+type TokenizerNoriProperties func() map[string]interface{}
+type TokenizerNori func(name string, properties ...TokenizerNoriProperties) TokenizerFunc
+
+func newTokenizerNori() TokenizerNori {
+	return func(name string, properties ...TokenizerNoriProperties) TokenizerFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "nori_tokenizer"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithDecompoundMode() sets whether compound tokens are kept, discarded or emitted along with their parts.
+func (n TokenizerNori) WithDecompoundMode(value TokenizerNoriDecompoundMode) TokenizerNoriProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"decompound_mode": value,
+		}
+	}
+}
+
+// WithDiscardPunctuation() sets whether punctuation is discarded from the output.
+func (n TokenizerNori) WithDiscardPunctuation(enabled bool) TokenizerNoriProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"discard_punctuation": enabled,
+		}
+	}
+}
+
+// WithUserDictionary() sets the path, relative to the config directory, of a user dictionary.
+func (n TokenizerNori) WithUserDictionary(value string) TokenizerNoriProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"user_dictionary": value,
+		}
+	}
+}
+
+// WithUserDictionaryRules() sets the user dictionary inline, one entry per rule (e.g., "세종시 세종 시").
+func (n TokenizerNori) WithUserDictionaryRules(value []string) TokenizerNoriProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"user_dictionary_rules": value,
+		}
+	}
+}
+
+// ICU FOLDING FILTER
+
+type FilterICUFoldingProperties func() map[string]interface{}
+type FilterICUFolding func(name string, properties ...FilterICUFoldingProperties) FilterFunc
+
+func newFilterICUFolding() FilterICUFolding {
+	return func(name string, properties ...FilterICUFoldingProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "icu_folding"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithUnicodeSetFilter() restricts folding to the characters of a UnicodeSet, e.g. "[^åäöÅÄÖ]".
+func (i FilterICUFolding) WithUnicodeSetFilter(value string) FilterICUFoldingProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"unicode_set_filter": value,
+		}
+	}
+}
+
+// ICU NORMALIZER FILTER
+
+// FilterICUNormalizerName defines the Unicode normalization form applied by the icu_normalizer filter.
+type FilterICUNormalizerName string
+
+var (
+	FilterICUNormalizerNameNFC    FilterICUNormalizerName = "nfc"
+	FilterICUNormalizerNameNFKC   FilterICUNormalizerName = "nfkc"
+	FilterICUNormalizerNameNFKCCF FilterICUNormalizerName = "nfkc_cf"
+)
+
+type FilterICUNormalizerProperties func() map[string]interface{}
+type FilterICUNormalizer func(name string, properties ...FilterICUNormalizerProperties) FilterFunc
+
+func newFilterICUNormalizer() FilterICUNormalizer {
+	return func(name string, properties ...FilterICUNormalizerProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "icu_normalizer"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithName() sets the normalization form (nfkc_cf by default).
+func (i FilterICUNormalizer) WithName(value FilterICUNormalizerName) FilterICUNormalizerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"name": value,
+		}
+	}
+}
+
+// WithUnicodeSetFilter() restricts normalization to the characters of a UnicodeSet.
+func (i FilterICUNormalizer) WithUnicodeSetFilter(value string) FilterICUNormalizerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"unicode_set_filter": value,
+		}
+	}
+}
+
+// ICU COLLATION FILTER
+
+// FilterICUCollationStrength defines which differences between characters are significant when collating.
+type FilterICUCollationStrength string
+
+var (
+	FilterICUCollationStrengthPrimary    FilterICUCollationStrength = "primary"
+	FilterICUCollationStrengthSecondary  FilterICUCollationStrength = "secondary"
+	FilterICUCollationStrengthTertiary   FilterICUCollationStrength = "tertiary"
+	FilterICUCollationStrengthQuaternary FilterICUCollationStrength = "quaternary"
+	FilterICUCollationStrengthIdentical  FilterICUCollationStrength = "identical"
+)
+
+type FilterICUCollationProperties func() map[string]interface{}
+type FilterICUCollation func(name string, properties ...FilterICUCollationProperties) FilterFunc
+
+func newFilterICUCollation() FilterICUCollation {
+	return func(name string, properties ...FilterICUCollationProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "icu_collation"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithLanguage() sets the ISO-639 language of the collation rules.
+func (i FilterICUCollation) WithLanguage(value string) FilterICUCollationProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"language": value,
+		}
+	}
+}
+
+// WithCountry() sets the ISO-3166 country of the collation rules.
+func (i FilterICUCollation) WithCountry(value string) FilterICUCollationProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"country": value,
+		}
+	}
+}
+
+// WithStrength() sets the comparison level.
+func (i FilterICUCollation) WithStrength(value FilterICUCollationStrength) FilterICUCollationProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"strength": value,
+		}
+	}
+}
+
+// WithNumeric() sets whether digits are sorted by their numeric value.
+func (i FilterICUCollation) WithNumeric(enabled bool) FilterICUCollationProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"numeric": enabled,
+		}
+	}
+}
+
+// PHONETIC FILTER
+
+// FilterPhoneticEncoder defines the phonetic algorithm used by the phonetic filter.
+type FilterPhoneticEncoder string
+
+var (
+	FilterPhoneticEncoderMetaphone       FilterPhoneticEncoder = "metaphone"
+	FilterPhoneticEncoderDoubleMetaphone FilterPhoneticEncoder = "double_metaphone"
+	FilterPhoneticEncoderSoundex         FilterPhoneticEncoder = "soundex"
+	FilterPhoneticEncoderRefinedSoundex  FilterPhoneticEncoder = "refined_soundex"
+	FilterPhoneticEncoderCaverphone1     FilterPhoneticEncoder = "caverphone1"
+	FilterPhoneticEncoderCaverphone2     FilterPhoneticEncoder = "caverphone2"
+	FilterPhoneticEncoderCologne         FilterPhoneticEncoder = "cologne"
+	FilterPhoneticEncoderNYSIIS          FilterPhoneticEncoder = "nysiis"
+	FilterPhoneticEncoderKoelnerPhonetik FilterPhoneticEncoder = "koelnerphonetik"
+	FilterPhoneticEncoderHaasePhonetik   FilterPhoneticEncoder = "haasephonetik"
+	FilterPhoneticEncoderBeiderMorse     FilterPhoneticEncoder = "beider_morse"
+	FilterPhoneticEncoderDaitchMokotoff  FilterPhoneticEncoder = "daitch_mokotoff"
+)
+
+type FilterPhoneticProperties func() map[string]interface{}
+type FilterPhonetic func(name string, encoder FilterPhoneticEncoder, properties ...FilterPhoneticProperties) FilterFunc
+
+func newFilterPhonetic() FilterPhonetic {
+	return func(name string, encoder FilterPhoneticEncoder, properties ...FilterPhoneticProperties) FilterFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "phonetic"
+			r["encoder"] = encoder
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithReplace() sets whether the original token is replaced by its encoding (true) or kept next to it (false).
+func (p FilterPhonetic) WithReplace(enabled bool) FilterPhoneticProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"replace": enabled,
+		}
+	}
+}
+
+// WithMaxCodeLen() sets the maximum length of the encoding (metaphone and double_metaphone).
+func (p FilterPhonetic) WithMaxCodeLen(value int) FilterPhoneticProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"max_code_len": value,
+		}
+	}
+}
+
+// WithLanguageSet() sets the languages considered by the beider_morse encoder.
+func (p FilterPhonetic) WithLanguageSet(value []string) FilterPhoneticProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"languageset": value,
+		}
+	}
+}
+
+// WithRuleType() sets whether the beider_morse encoder matches exactly or approximately ("exact" or "approx").
+func (p FilterPhonetic) WithRuleType(value string) FilterPhoneticProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"rule_type": value,
+		}
+	}
+}
+
+// WithNameType() sets the kind of names the beider_morse encoder expects ("generic", "ashkenazi" or "sephardic").
+func (p FilterPhonetic) WithNameType(value string) FilterPhoneticProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"name_type": value,
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/elastic/go-elasticsearch/v8"
@@ -18,12 +19,23 @@ var (
 	ErrClientCreatingDocuments = fmt.Errorf("elasticsearch client: bulk insert operation failed")
 	ErrClientDeletingIndex     = fmt.Errorf("elasticsearch client: failed to delete index")
 	ErrClientDeletingDocuments = fmt.Errorf("elasticsearch client: failed to delete documents by query")
+	ErrClientSearching         = fmt.Errorf("elasticsearch client: search request failed")
+	ErrClientPuttingSynonyms   = fmt.Errorf("elasticsearch client: failed to put synonyms set")
+	ErrClientDeletingSynonyms  = fmt.Errorf("elasticsearch client: failed to delete synonyms set")
+	ErrClientReloadingAnalyzer = fmt.Errorf("elasticsearch client: failed to reload search analyzers")
+	ErrClientAnalyzing         = fmt.Errorf("elasticsearch client: analyze request failed")
+	ErrClientListingPlugins    = fmt.Errorf("elasticsearch client: failed to list plugins")
 
 	ErrMigratorMigratingIndex = fmt.Errorf("migrator: index operation failed during migration process")
 	ErrMigratorDocuments      = fmt.Errorf("migrator: document operation failed during migration process")
 
 	ErrPorterMigratingUp   = fmt.Errorf("porter: failed to perform 'up' migration")
 	ErrPorterMigratingDown = fmt.Errorf("porter: failed to perform 'down' migration")
+	ErrPorterSearching     = fmt.Errorf("porter: failed to perform verification search")
+	ErrPorterSynonyms      = fmt.Errorf("porter: failed to update synonyms")
+	ErrPorterAnalyzing     = fmt.Errorf("porter: failed to analyze text")
+	ErrPorterPlugins       = fmt.Errorf("porter: failed to check analysis plugins")
+	ErrPorterEmulating     = fmt.Errorf("porter: failed to emulate analysis")
 )
 
 // Constants for migration direction
@@ -46,20 +58,26 @@ type index struct {
 	Mappings mappings
 }
 
-// settings{} represents the analysis settings of the index, including analyzers and normalizers
+// settings{} represents the analysis settings of the index, including analyzers and normalizers, and its similarities
 type settings struct {
-	Analysis analysis
+	Analysis   analysis
+	Similarity similarity
 }
 
-// analysis{} defines the analyzers and normalizers for the index.
+// analysis{} defines the analyzers, normalizers, tokenizers, token filters and character filters for the index.
 type analysis struct {
 	Analyzer   analyzer
 	Normalizer normalizer
+	Tokenizer  tokenizer
+	Filter     filter
+	CharFilter charFilter
+	Synonyms   synonyms
 }
 
-// mappings{} defines the properties of the index, i.e., the fields in the documents.
+// mappings{} defines the properties of the index, i.e., the fields in the documents, and its runtime fields.
 type mappings struct {
 	Properties properties
+	Runtime    runtime
 }
 
 // properties{} defines various field types in the index.
@@ -83,6 +101,12 @@ type searcher interface {
 	CreateDocuments(ctx context.Context, name string, documents []byte) error
 	DeleteIndex(ctx context.Context, name string) error
 	DeleteDocuments(ctx context.Context, name string, query string) error
+	Search(ctx context.Context, name string, body []byte) (SearchResult, error)
+	PutSynonymsSet(ctx context.Context, id string, body []byte) error
+	DeleteSynonymsSet(ctx context.Context, id string) error
+	ReloadSearchAnalyzers(ctx context.Context, name string) error
+	Analyze(ctx context.Context, name string, body []byte) ([]Token, error)
+	CatPlugins(ctx context.Context) ([]Plugin, error)
 }
 
 // client{} wraps the Elasticsearch client and provides convenience methods for interacting with Elasticsearch.
@@ -196,6 +220,165 @@ func (c client) DeleteDocuments(ctx context.Context, name string, query string)
 	return nil
 }
 
+func (c client) Search(ctx context.Context, name string, body []byte) (SearchResult, error) {
+	resp, err := c.Client.Search(
+		c.Client.Search.WithContext(ctx),
+		c.Client.Search.WithIndex(name),
+		c.Client.Search.WithBody(bytes.NewBuffer(body)),
+	)
+	if err != nil {
+		return SearchResult{}, fmt.Errorf("%w [%s]", ErrClientBadConnection, err)
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return SearchResult{}, fmt.Errorf("%w [%s]", ErrClientBadConnection, err)
+	}
+
+	r, ok := utils.ExtractError(io.NopCloser(bytes.NewReader(contents)))
+	if ok {
+		return SearchResult{}, fmt.Errorf("%w [%s]", ErrClientSearching, r)
+	}
+
+	var sr struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+			Hits []SearchHit `json:"hits"`
+		} `json:"hits"`
+	}
+
+	err = json.Unmarshal(contents, &sr)
+	if err != nil {
+		return SearchResult{}, fmt.Errorf("%w [%s]", ErrClientSearching, err)
+	}
+
+	return SearchResult{
+		Total: sr.Hits.Total.Value,
+		Hits:  sr.Hits.Hits,
+	}, nil
+}
+
+func (c client) PutSynonymsSet(ctx context.Context, id string, body []byte) error {
+	resp, err := c.SynonymsPutSynonym(
+		id,
+		bytes.NewBuffer(body),
+		c.SynonymsPutSynonym.WithContext(ctx),
+		c.SynonymsPutSynonym.WithPretty(),
+	)
+	if err != nil {
+		return fmt.Errorf("%w [%s]", ErrClientBadConnection, err)
+	}
+	defer resp.Body.Close()
+
+	r, ok := utils.ExtractError(resp.Body)
+	if ok {
+		return fmt.Errorf("%w [%s]", ErrClientPuttingSynonyms, r)
+	}
+	return nil
+}
+
+func (c client) DeleteSynonymsSet(ctx context.Context, id string) error {
+	resp, err := c.SynonymsDeleteSynonym(
+		id,
+		c.SynonymsDeleteSynonym.WithContext(ctx),
+		c.SynonymsDeleteSynonym.WithPretty(),
+	)
+	if err != nil {
+		return fmt.Errorf("%w [%s]", ErrClientBadConnection, err)
+	}
+	defer resp.Body.Close()
+
+	r, ok := utils.ExtractError(resp.Body)
+	if ok {
+		return fmt.Errorf("%w [%s]", ErrClientDeletingSynonyms, r)
+	}
+	return nil
+}
+
+func (c client) ReloadSearchAnalyzers(ctx context.Context, name string) error {
+	resp, err := c.Indices.ReloadSearchAnalyzers(
+		[]string{name},
+		c.Indices.ReloadSearchAnalyzers.WithContext(ctx),
+		c.Indices.ReloadSearchAnalyzers.WithPretty(),
+	)
+	if err != nil {
+		return fmt.Errorf("%w [%s]", ErrClientBadConnection, err)
+	}
+	defer resp.Body.Close()
+
+	r, ok := utils.ExtractError(resp.Body)
+	if ok {
+		return fmt.Errorf("%w [%s]", ErrClientReloadingAnalyzer, r)
+	}
+	return nil
+}
+
+func (c client) Analyze(ctx context.Context, name string, body []byte) ([]Token, error) {
+	resp, err := c.Indices.Analyze(
+		c.Indices.Analyze.WithContext(ctx),
+		c.Indices.Analyze.WithIndex(name),
+		c.Indices.Analyze.WithBody(bytes.NewBuffer(body)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w [%s]", ErrClientBadConnection, err)
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w [%s]", ErrClientBadConnection, err)
+	}
+
+	r, ok := utils.ExtractError(io.NopCloser(bytes.NewReader(contents)))
+	if ok {
+		return nil, fmt.Errorf("%w [%s]", ErrClientAnalyzing, r)
+	}
+
+	var ar struct {
+		Tokens []Token `json:"tokens"`
+	}
+
+	err = json.Unmarshal(contents, &ar)
+	if err != nil {
+		return nil, fmt.Errorf("%w [%s]", ErrClientAnalyzing, err)
+	}
+
+	return ar.Tokens, nil
+}
+
+func (c client) CatPlugins(ctx context.Context) ([]Plugin, error) {
+	resp, err := c.Cat.Plugins(
+		c.Cat.Plugins.WithContext(ctx),
+		c.Cat.Plugins.WithFormat("json"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w [%s]", ErrClientBadConnection, err)
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w [%s]", ErrClientBadConnection, err)
+	}
+
+	r, ok := utils.ExtractError(io.NopCloser(bytes.NewReader(contents)))
+	if ok {
+		return nil, fmt.Errorf("%w [%s]", ErrClientListingPlugins, r)
+	}
+
+	var plugins []Plugin
+
+	err = json.Unmarshal(contents, &plugins)
+	if err != nil {
+		return nil, fmt.Errorf("%w [%s]", ErrClientListingPlugins, err)
+	}
+
+	return plugins, nil
+}
+
 // New() initializes and returns a new migration object.
 func New(cc *elasticsearch.Client) M {
 	return M{
@@ -214,26 +397,120 @@ func New(cc *elasticsearch.Client) M {
 						Custom:      newAnalyzerCustom(),
 					},
 					Normalizer: normalizer{
-						Custom: newNormalizerCustom(),
+						Lowercase: newNormalizerLowercase(),
+						Custom:    newNormalizerCustom(),
+					},
+					Tokenizer: tokenizer{
+						NGram:         newTokenizerNGram(),
+						EdgeNGram:     newTokenizerEdgeNGram(),
+						Pattern:       newTokenizerPattern(),
+						CharGroup:     newTokenizerCharGroup(),
+						PathHierarchy: newTokenizerPathHierarchy(),
+						Classic:       newTokenizerClassic(),
+
+						ICU:      newTokenizerICU(),
+						Kuromoji: newTokenizerKuromoji(),
+						Nori:     newTokenizerNori(),
+					},
+					Filter: filter{
+						ASCIIFolding:       newFilterASCIIFolding(),
+						EdgeNGram:          newFilterEdgeNGram(),
+						Elision:            newFilterElision(),
+						KeepWords:          newFilterKeepWords(),
+						KeywordMarker:      newFilterKeywordMarker(),
+						Length:             newFilterLength(),
+						NGram:              newFilterNGram(),
+						PatternReplace:     newFilterPatternReplace(),
+						Shingle:            newFilterShingle(),
+						Stemmer:            newFilterStemmer(),
+						Stop:               newFilterStop(),
+						Synonym:            newFilterSynonym(),
+						SynonymGraph:       newFilterSynonymGraph(),
+						WordDelimiterGraph: newFilterWordDelimiterGraph(),
+
+						ICUFolding:    newFilterICUFolding(),
+						ICUNormalizer: newFilterICUNormalizer(),
+						ICUCollation:  newFilterICUCollation(),
+						Phonetic:      newFilterPhonetic(),
+					},
+					CharFilter: charFilter{
+						HTMLStrip:      newCharFilterHTMLStrip(),
+						Mapping:        newCharFilterMapping(),
+						PatternReplace: newCharFilterPatternReplace(),
+					},
+					Synonyms: synonyms{
+						Equivalent: newSynonymsEquivalent(),
+						Explicit:   newSynonymsExplicit(),
 					},
 				},
+				Similarity: similarity{
+					BM25:            newSimilarityBM25(),
+					DFR:             newSimilarityDFR(),
+					DFI:             newSimilarityDFI(),
+					IB:              newSimilarityIB(),
+					LMDirichlet:     newSimilarityLMDirichlet(),
+					LMJelinekMercer: newSimilarityLMJelinekMercer(),
+					Scripted:        newSimilarityScripted(),
+				},
 			},
 			Mappings: mappings{
+				Runtime: runtime{
+					Keyword:   newRuntimeKeyword(),
+					Long:      newRuntimeLong(),
+					Double:    newRuntimeDouble(),
+					Date:      newRuntimeDate(),
+					IP:        newRuntimeIP(),
+					Boolean:   newRuntimeBoolean(),
+					GeoPoint:  newRuntimeGeoPoint(),
+					Composite: newRuntimeComposite(),
+				},
 				Properties: properties{
 					fields: fields{
-						Keyword:     newFieldKeyword(),
-						Text:        newFieldText(),
-						Integer:     newFieldInteger(),
-						Long:        newFieldLong(),
-						Float:       newFieldFloat(),
-						Double:      newFieldDouble(),
-						Short:       newFieldShort(),
-						Byte:        newFieldByte(),
-						HalfFloat:   newFieldHalfFloat(),
-						ScaledFloat: newFieldScaledFloat(),
-						Date:        newFieldDate(),
-						Boolean:     newFieldBoolean(),
-						IP:          newFieldIP(),
+						Keyword:               newFieldKeyword(),
+						Text:                  newFieldText(),
+						Integer:               newFieldInteger(),
+						Long:                  newFieldLong(),
+						Float:                 newFieldFloat(),
+						Double:                newFieldDouble(),
+						Short:                 newFieldShort(),
+						Byte:                  newFieldByte(),
+						HalfFloat:             newFieldHalfFloat(),
+						ScaledFloat:           newFieldScaledFloat(),
+						Date:                  newFieldDate(),
+						Boolean:               newFieldBoolean(),
+						IP:                    newFieldIP(),
+						Object:                newFieldObject(),
+						Nested:                newFieldNested(),
+						GeoPoint:              newFieldGeoPoint(),
+						GeoShape:              newFieldGeoShape(),
+						Point:                 newFieldPoint(),
+						Shape:                 newFieldShape(),
+						DenseVector:           newFieldDenseVector(),
+						SparseVector:          newFieldSparseVector(),
+						RankFeatures:          newFieldRankFeatures(),
+						Completion:            newFieldCompletion(),
+						SearchAsYouType:       newFieldSearchAsYouType(),
+						IntegerRange:          newFieldIntegerRange(),
+						LongRange:             newFieldLongRange(),
+						FloatRange:            newFieldFloatRange(),
+						DoubleRange:           newFieldDoubleRange(),
+						DateRange:             newFieldDateRange(),
+						IPRange:               newFieldIPRange(),
+						Wildcard:              newFieldWildcard(),
+						ConstantKeyword:       newFieldConstantKeyword(),
+						Flattened:             newFieldFlattened(),
+						MatchOnlyText:         newFieldMatchOnlyText(),
+						Join:                  newFieldJoin(),
+						UnsignedLong:          newFieldUnsignedLong(),
+						DateNanos:             newFieldDateNanos(),
+						Binary:                newFieldBinary(),
+						Version:               newFieldVersion(),
+						TokenCount:            newFieldTokenCount(),
+						Histogram:             newFieldHistogram(),
+						AggregateMetricDouble: newFieldAggregateMetricDouble(),
+						RankFeature:           newFieldRankFeature(),
+						Percolator:            newFieldPercolator(),
+						Alias:                 newFieldAlias(),
 					},
 				},
 			},
@@ -241,8 +518,11 @@ func New(cc *elasticsearch.Client) M {
 		Documents: documents{
 			Origin: origin{
 				location: location{
-					FromFile: newLocationFromFile(),
-					Generate: newLocationGenerate(),
+					FromFile:     newLocationFromFile(),
+					Generate:     newLocationGenerate(),
+					GenerateJoin: newLocationGenerateJoin(),
+
+					GenerateTimeSeries: newLocationGenerateTimeSeries(),
 				},
 			},
 		},
@@ -303,6 +583,100 @@ func (m M) MigrateDown(config Config, documents documentsFunc, index IndexFunc)
 	return nil
 }
 
+// Validate() checks the index definition offline, the same way MigrateIndex() does before creating the index, and returns every problem found in it.
+func (m M) Validate(config Config) ValidationReport {
+	return newValidationReport(config.Definition)
+}
+
+// CheckPlugins() verifies that the analysis plugins used by the index definition (e.g., analysis-icu for an icu_tokenizer) are installed on the cluster.
+func (m M) CheckPlugins(ctx context.Context, config Config) error {
+	err := checkPlugins(ctx, m.Client, config.Definition)
+	if err != nil {
+		return fmt.Errorf("%w\n%v", ErrPorterPlugins, err)
+	}
+
+	return nil
+}
+
+// SearchResult{} represents the outcome of a verification search.
+type SearchResult struct {
+	Total int
+	Hits  []SearchHit
+}
+
+// SearchHit{} represents a single document returned by a verification search, including the values of requested (runtime) fields.
+type SearchHit struct {
+	ID     string                 `json:"_id"`
+	Source map[string]interface{} `json:"_source"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// Search() runs a verification query against the index, e.g. to check search-time runtime mappings before they are indexed.
+func (m M) Search(ctx context.Context, config Config, search SearchConfig) (SearchResult, error) {
+	r, err := m.Client.Search(ctx, config.Name, utils.MarshalJSON(search))
+	if err != nil {
+		return SearchResult{}, fmt.Errorf("%w\n%v", ErrPorterSearching, err)
+	}
+
+	return r, nil
+}
+
+// Token{} represents a single token produced by an analyzer.
+type Token struct {
+	Term        string `json:"token"`
+	StartOffset int    `json:"start_offset"`
+	EndOffset   int    `json:"end_offset"`
+	Type        string `json:"type"`
+	Position    int    `json:"position"`
+}
+
+// Analyze() runs text through an analyzer of the index (custom, language or built-in) and returns the produced token stream.
+func (m M) Analyze(ctx context.Context, index string, analyzer string, text string) ([]Token, error) {
+	body := map[string]interface{}{
+		"analyzer": analyzer,
+		"text":     text,
+	}
+
+	r, err := m.Client.Analyze(ctx, index, utils.MarshalJSON(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w\n%v", ErrPorterAnalyzing, err)
+	}
+
+	return r, nil
+}
+
+// Emulate() runs text through an analyzer of the index definition offline, without a cluster. Only common analyzers and filters are emulated (see emulator.go); any other component is reported in the error.
+func (m M) Emulate(config Config, analyzer string, text string) ([]Token, error) {
+	var analysis *AnalysisConfig
+	if config.Definition.Settings != nil {
+		analysis = config.Definition.Settings.Analysis
+	}
+
+	r, err := emulate(analysis, analyzer, text)
+	if err != nil {
+		return nil, fmt.Errorf("%w\n%v", ErrPorterEmulating, err)
+	}
+
+	return r, nil
+}
+
+// UpdateSynonyms() stores the synonym sets through the Synonyms API and reloads the search analyzers of the index, so updateable synonym filters use the new rules without a reindex.
+func (m M) UpdateSynonyms(ctx context.Context, config Config, sets ...SynonymsSetConfig) error {
+	for _, set := range sets {
+		err := m.Client.PutSynonymsSet(ctx, set.ID, utils.MarshalJSON(set))
+		if err != nil {
+			return fmt.Errorf("%w\n%v", ErrPorterSynonyms, err)
+		}
+	}
+
+	err := m.Client.ReloadSearchAnalyzers(ctx, config.Name)
+	if err != nil {
+		return fmt.Errorf("%w\n%v", ErrPorterSynonyms, err)
+	}
+
+	return nil
+}
+
 type IndexFunc func(t Temp) error
 
 // NoIndex() represents no operation for the index during migration (used for down migrations).
@@ -316,7 +690,12 @@ func (i index) NoIndex() IndexFunc {
 func (i index) MigrateIndex() IndexFunc {
 	return func(t Temp) error {
 		if t.direction == directionUp {
-			err := t.Client.CreateIndex(context.Background(), t.Config.Name, utils.MarshalJSON(t.Config.Definition))
+			err := checkIndex(t)
+			if err != nil {
+				return fmt.Errorf("%w\n%s", ErrMigratorMigratingIndex, err)
+			}
+
+			err = t.Client.CreateIndex(context.Background(), t.Config.Name, utils.MarshalJSON(t.Config.Definition))
 			if err != nil {
 				return fmt.Errorf("%w\n%s", ErrMigratorMigratingIndex, err)
 			}
@@ -331,6 +710,70 @@ func (i index) MigrateIndex() IndexFunc {
 	}
 }
 
+// MigrateIndexWithSynonyms() migrates the index like MigrateIndex(), and also manages the synonym sets it references: they are stored before the index is created, and deleted after the index is deleted. If the index cannot be created, the stored sets are deleted again.
+func (i index) MigrateIndexWithSynonyms(sets ...SynonymsSetConfig) IndexFunc {
+	migrate := i.MigrateIndex()
+
+	return func(t Temp) error {
+		if t.direction == directionUp {
+			err := checkIndex(t)
+			if err != nil {
+				return fmt.Errorf("%w\n%s", ErrMigratorMigratingIndex, err)
+			}
+
+			for c, set := range sets {
+				err := t.Client.PutSynonymsSet(context.Background(), set.ID, utils.MarshalJSON(set))
+				if err != nil {
+					return fmt.Errorf("%w\n%s%s", ErrMigratorMigratingIndex, err, deleteSynonymsSets(t, sets[:c]))
+				}
+			}
+
+			err = t.Client.CreateIndex(context.Background(), t.Config.Name, utils.MarshalJSON(t.Config.Definition))
+			if err != nil {
+				return fmt.Errorf("%w\n%s%s", ErrMigratorMigratingIndex, err, deleteSynonymsSets(t, sets))
+			}
+			return nil
+		} else {
+			err := migrate(t)
+			if err != nil {
+				return err
+			}
+
+			for _, set := range sets {
+				err := t.Client.DeleteSynonymsSet(context.Background(), set.ID)
+				if err != nil {
+					return fmt.Errorf("%w\n%s", ErrMigratorMigratingIndex, err)
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// checkIndex() runs the offline and cluster checks that must pass before the index is created.
+func checkIndex(t Temp) error {
+	err := validateDefinition(t.Config.Definition)
+	if err != nil {
+		return err
+	}
+
+	return checkPlugins(context.Background(), t.Client, t.Config.Definition)
+}
+
+// deleteSynonymsSets() removes synonym sets stored by a failed up migration. Sets that cannot be deleted are returned as lines to append to the migration error.
+func deleteSynonymsSets(t Temp, sets []SynonymsSetConfig) string {
+	var r string
+
+	for _, set := range sets {
+		err := t.Client.DeleteSynonymsSet(context.Background(), set.ID)
+		if err != nil {
+			r += fmt.Sprintf("\n%s", err)
+		}
+	}
+
+	return r
+}
+
 type documentsFunc func(t Temp) error
 
 // NoDocuments() represents no operation for documents during migration (used for down migrations).
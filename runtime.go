@@ -0,0 +1,449 @@
+package porter
+
+/*
+
+This file defines the builders for Elasticsearch runtime fields. Runtime fields are evaluated at
+query time by a painless script (or read from _source when no script is given), which makes them a
+cheap way to prototype a field before it is indexed.
+
+The NewRuntime() function composes runtime field definitions into a map that can be placed either
+into MappingsConfig.Runtime (index-time runtime section) or into SearchConfig.RuntimeMappings
+(search-time runtime mappings used by verification queries).
+
+*/
+
+type runtime struct {
+	Keyword   RuntimeKeyword
+	Long      RuntimeLong
+	Double    RuntimeDouble
+	Date      RuntimeDate
+	IP        RuntimeIP
+	Boolean   RuntimeBoolean
+	GeoPoint  RuntimeGeoPoint
+	Composite RuntimeComposite
+}
+
+// NewRuntime() generates a map of runtime field names and their corresponding definitions.
+func (m mappings) NewRuntime(fields ...RuntimeFunc) map[string]interface{} {
+	r := map[string]interface{}{}
+
+	for _, fn := range fields {
+		if fn == nil {
+			continue
+		}
+		for k, v := range fn() {
+			r[k] = v
+		}
+	}
+
+	return r
+}
+
+type RuntimeFunc func() map[string]interface{}
+
+// RuntimeOnScriptError defines what happens when the script of a runtime field fails.
+type RuntimeOnScriptError string
+
+var (
+	RuntimeOnScriptErrorFail     RuntimeOnScriptError = "fail"
+	RuntimeOnScriptErrorContinue RuntimeOnScriptError = "continue"
+)
+
+// KEYWORD
+
+type RuntimeKeywordProperties func() map[string]interface{}
+type RuntimeKeyword func(name string, properties ...RuntimeKeywordProperties) RuntimeFunc
+
+func newRuntimeKeyword() RuntimeKeyword {
+	return func(name string, properties ...RuntimeKeywordProperties) RuntimeFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "keyword"
+
+		return func() map[string]interface{} {
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithScript() adds a "script" property to a keyword runtime field.
+func (k RuntimeKeyword) WithScript(value ScriptConfig) RuntimeKeywordProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
+
+// WithOnScriptError() adds an "on_script_error" property to a keyword runtime field.
+func (k RuntimeKeyword) WithOnScriptError(value RuntimeOnScriptError) RuntimeKeywordProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
+
+// LONG
+
+type RuntimeLongProperties func() map[string]interface{}
+type RuntimeLong func(name string, properties ...RuntimeLongProperties) RuntimeFunc
+
+func newRuntimeLong() RuntimeLong {
+	return func(name string, properties ...RuntimeLongProperties) RuntimeFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "long"
+
+		return func() map[string]interface{} {
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithScript() adds a "script" property to a long runtime field.
+func (l RuntimeLong) WithScript(value ScriptConfig) RuntimeLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
+
+// WithOnScriptError() adds an "on_script_error" property to a long runtime field.
+func (l RuntimeLong) WithOnScriptError(value RuntimeOnScriptError) RuntimeLongProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
+
+// DOUBLE
+
+type RuntimeDoubleProperties func() map[string]interface{}
+type RuntimeDouble func(name string, properties ...RuntimeDoubleProperties) RuntimeFunc
+
+func newRuntimeDouble() RuntimeDouble {
+	return func(name string, properties ...RuntimeDoubleProperties) RuntimeFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "double"
+
+		return func() map[string]interface{} {
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithScript() adds a "script" property to a double runtime field.
+func (d RuntimeDouble) WithScript(value ScriptConfig) RuntimeDoubleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
+
+// WithOnScriptError() adds an "on_script_error" property to a double runtime field.
+func (d RuntimeDouble) WithOnScriptError(value RuntimeOnScriptError) RuntimeDoubleProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
+
+// DATE
+
+type RuntimeDateProperties func() map[string]interface{}
+type RuntimeDate func(name string, properties ...RuntimeDateProperties) RuntimeFunc
+
+func newRuntimeDate() RuntimeDate {
+	return func(name string, properties ...RuntimeDateProperties) RuntimeFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "date"
+
+		return func() map[string]interface{} {
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithScript() adds a "script" property to a date runtime field.
+func (d RuntimeDate) WithScript(value ScriptConfig) RuntimeDateProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
+
+// WithFormat() adds a "format" property to a date runtime field.
+func (d RuntimeDate) WithFormat(value string) RuntimeDateProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"format": value,
+		}
+	}
+}
+
+// WithOnScriptError() adds an "on_script_error" property to a date runtime field.
+func (d RuntimeDate) WithOnScriptError(value RuntimeOnScriptError) RuntimeDateProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
+
+// IP
+
+type RuntimeIPProperties func() map[string]interface{}
+type RuntimeIP func(name string, properties ...RuntimeIPProperties) RuntimeFunc
+
+func newRuntimeIP() RuntimeIP {
+	return func(name string, properties ...RuntimeIPProperties) RuntimeFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "ip"
+
+		return func() map[string]interface{} {
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithScript() adds a "script" property to an IP runtime field.
+func (i RuntimeIP) WithScript(value ScriptConfig) RuntimeIPProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
+
+// WithOnScriptError() adds an "on_script_error" property to an IP runtime field.
+func (i RuntimeIP) WithOnScriptError(value RuntimeOnScriptError) RuntimeIPProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
+
+// BOOLEAN
+
+type RuntimeBooleanProperties func() map[string]interface{}
+type RuntimeBoolean func(name string, properties ...RuntimeBooleanProperties) RuntimeFunc
+
+func newRuntimeBoolean() RuntimeBoolean {
+	return func(name string, properties ...RuntimeBooleanProperties) RuntimeFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "boolean"
+
+		return func() map[string]interface{} {
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithScript() adds a "script" property to a boolean runtime field.
+func (b RuntimeBoolean) WithScript(value ScriptConfig) RuntimeBooleanProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
+
+// WithOnScriptError() adds an "on_script_error" property to a boolean runtime field.
+func (b RuntimeBoolean) WithOnScriptError(value RuntimeOnScriptError) RuntimeBooleanProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
+
+// GEOPOINT
+
+type RuntimeGeoPointProperties func() map[string]interface{}
+type RuntimeGeoPoint func(name string, properties ...RuntimeGeoPointProperties) RuntimeFunc
+
+func newRuntimeGeoPoint() RuntimeGeoPoint {
+	return func(name string, properties ...RuntimeGeoPointProperties) RuntimeFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "geo_point"
+
+		return func() map[string]interface{} {
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithScript() adds a "script" property to a geo_point runtime field.
+func (g RuntimeGeoPoint) WithScript(value ScriptConfig) RuntimeGeoPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"script": value,
+		}
+	}
+}
+
+// WithOnScriptError() adds an "on_script_error" property to a geo_point runtime field.
+func (g RuntimeGeoPoint) WithOnScriptError(value RuntimeOnScriptError) RuntimeGeoPointProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
+
+// COMPOSITE
+
+type RuntimeCompositeProperties func() map[string]interface{}
+type RuntimeComposite func(name string, script ScriptConfig, properties ...RuntimeCompositeProperties) RuntimeFunc
+
+func newRuntimeComposite() RuntimeComposite {
+	return func(name string, script ScriptConfig, properties ...RuntimeCompositeProperties) RuntimeFunc {
+		r := map[string]interface{}{}
+
+		for _, fn := range properties {
+			if fn == nil {
+				continue
+			}
+			for k, v := range fn() {
+				r[k] = v
+			}
+		}
+
+		r["type"] = "composite"
+		r["script"] = script
+
+		return func() map[string]interface{} {
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// RuntimeCompositeFieldType defines the types a sub-field of a composite runtime field can have.
+type RuntimeCompositeFieldType string
+
+var (
+	RuntimeCompositeFieldTypeKeyword  RuntimeCompositeFieldType = "keyword"
+	RuntimeCompositeFieldTypeLong     RuntimeCompositeFieldType = "long"
+	RuntimeCompositeFieldTypeDouble   RuntimeCompositeFieldType = "double"
+	RuntimeCompositeFieldTypeDate     RuntimeCompositeFieldType = "date"
+	RuntimeCompositeFieldTypeIP       RuntimeCompositeFieldType = "ip"
+	RuntimeCompositeFieldTypeBoolean  RuntimeCompositeFieldType = "boolean"
+	RuntimeCompositeFieldTypeGeoPoint RuntimeCompositeFieldType = "geo_point"
+)
+
+// WithFields() defines the sub-fields emitted by the script of a composite runtime field.
+func (c RuntimeComposite) WithFields(value map[string]RuntimeCompositeFieldType) RuntimeCompositeProperties {
+	return func() map[string]interface{} {
+		f := map[string]interface{}{}
+
+		for k, v := range value {
+			f[k] = map[string]interface{}{
+				"type": v,
+			}
+		}
+
+		return map[string]interface{}{
+			"fields": f,
+		}
+	}
+}
+
+// WithOnScriptError() adds an "on_script_error" property to a composite runtime field.
+func (c RuntimeComposite) WithOnScriptError(value RuntimeOnScriptError) RuntimeCompositeProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"on_script_error": value,
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package porter
+
+/*
+
+This file contains the factory functions and types for constructing custom similarities. A similarity
+defines how matching documents are scored; once defined in the index settings, it is referenced by name
+from FieldKeyword.WithSimilarity() or FieldText.WithSimilarity().
+
+Each similarity is implemented as a composable function with optional configuration helpers (e.g.,
+WithK1(), WithB()), and the NewSimilarity() function compiles them into a map that is placed into
+SettingsConfig.Similarity.
+
+*/
+
+type similarity struct {
+	BM25            SimilarityBM25
+	DFR             SimilarityDFR
+	DFI             SimilarityDFI
+	IB              SimilarityIB
+	LMDirichlet     SimilarityLMDirichlet
+	LMJelinekMercer SimilarityLMJelinekMercer
+	Scripted        SimilarityScripted
+}
+
+// NewSimilarity() generates a map of similarity names and their corresponding definitions.
+func (s settings) NewSimilarity(similarities ...SimilarityFunc) map[string]interface{} {
+	r := map[string]interface{}{}
+
+	for _, fn := range similarities {
+		if fn == nil {
+			continue
+		}
+		for k, v := range fn() {
+			r[k] = v
+		}
+	}
+
+	return r
+}
+
+type SimilarityFunc func() map[string]interface{}
+
+// SimilarityNormalizationType defines the term frequency normalization used by the DFR and IB similarities.
+type SimilarityNormalizationType string
+
+var (
+	SimilarityNormalizationNo SimilarityNormalizationType = "no"
+	SimilarityNormalizationH1 SimilarityNormalizationType = "h1"
+	SimilarityNormalizationH2 SimilarityNormalizationType = "h2"
+	SimilarityNormalizationH3 SimilarityNormalizationType = "h3"
+	SimilarityNormalizationZ  SimilarityNormalizationType = "z"
+)
+
+// SimilarityNormalization{} defines a normalization and its parameter ("c" for h1/h2, "mu" for h3, "z" for z). A zero Value keeps the Elasticsearch default.
+type SimilarityNormalization struct {
+	Type  SimilarityNormalizationType
+	Value float64
+}
+
+func (n SimilarityNormalization) properties() map[string]interface{} {
+	r := map[string]interface{}{
+		"normalization": n.Type,
+	}
+
+	if n.Value == 0 {
+		return r
+	}
+
+	switch n.Type {
+	case SimilarityNormalizationH1, SimilarityNormalizationH2:
+		r["normalization."+string(n.Type)+".c"] = n.Value
+	case SimilarityNormalizationH3:
+		r["normalization.h3.mu"] = n.Value
+	case SimilarityNormalizationZ:
+		r["normalization.z.z"] = n.Value
+	}
+
+	return r
+}
+
+// BM25 SIMILARITY
+
+type SimilarityBM25Properties func() map[string]interface{}
+type SimilarityBM25 func(name string, properties ...SimilarityBM25Properties) SimilarityFunc
+
+func newSimilarityBM25() SimilarityBM25 {
+	return func(name string, properties ...SimilarityBM25Properties) SimilarityFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "BM25"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithK1() sets the k1 parameter (term frequency saturation) of the BM25 similarity.
+func (b SimilarityBM25) WithK1(value float64) SimilarityBM25Properties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"k1": value,
+		}
+	}
+}
+
+// WithB() sets the b parameter (document length normalization) of the BM25 similarity.
+func (b SimilarityBM25) WithB(value float64) SimilarityBM25Properties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"b": value,
+		}
+	}
+}
+
+// WithDiscountOverlaps() sets whether overlapping tokens are ignored when computing the norm.
+func (b SimilarityBM25) WithDiscountOverlaps(enabled bool) SimilarityBM25Properties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"discount_overlaps": enabled,
+		}
+	}
+}
+
+// DFR SIMILARITY
+
+type SimilarityDFRBasicModel string
+
+var (
+	SimilarityDFRBasicModelG   SimilarityDFRBasicModel = "g"
+	SimilarityDFRBasicModelIF  SimilarityDFRBasicModel = "if"
+	SimilarityDFRBasicModelIN  SimilarityDFRBasicModel = "in"
+	SimilarityDFRBasicModelINE SimilarityDFRBasicModel = "ine"
+)
+
+type SimilarityDFRAfterEffect string
+
+var (
+	SimilarityDFRAfterEffectB SimilarityDFRAfterEffect = "b"
+	SimilarityDFRAfterEffectL SimilarityDFRAfterEffect = "l"
+)
+
+type SimilarityDFR func(name string, basicModel SimilarityDFRBasicModel, afterEffect SimilarityDFRAfterEffect, normalization SimilarityNormalization) SimilarityFunc
+
+func newSimilarityDFR() SimilarityDFR {
+	return func(name string, basicModel SimilarityDFRBasicModel, afterEffect SimilarityDFRAfterEffect, normalization SimilarityNormalization) SimilarityFunc {
+		return func() map[string]interface{} {
+			r := normalization.properties()
+
+			r["type"] = "DFR"
+			r["basic_model"] = basicModel
+			r["after_effect"] = afterEffect
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// DFI SIMILARITY
+
+type SimilarityDFIIndependenceMeasure string
+
+var (
+	SimilarityDFIIndependenceMeasureStandardized SimilarityDFIIndependenceMeasure = "standardized"
+	SimilarityDFIIndependenceMeasureSaturated    SimilarityDFIIndependenceMeasure = "saturated"
+	SimilarityDFIIndependenceMeasureChiSquared   SimilarityDFIIndependenceMeasure = "chisquared"
+)
+
+type SimilarityDFI func(name string, independenceMeasure SimilarityDFIIndependenceMeasure) SimilarityFunc
+
+func newSimilarityDFI() SimilarityDFI {
+	return func(name string, independenceMeasure SimilarityDFIIndependenceMeasure) SimilarityFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			r["type"] = "DFI"
+			r["independence_measure"] = independenceMeasure
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// IB SIMILARITY
+
+type SimilarityIBDistribution string
+
+var (
+	SimilarityIBDistributionLL  SimilarityIBDistribution = "ll"
+	SimilarityIBDistributionSPL SimilarityIBDistribution = "spl"
+)
+
+type SimilarityIBLambda string
+
+var (
+	SimilarityIBLambdaDF  SimilarityIBLambda = "df"
+	SimilarityIBLambdaTTF SimilarityIBLambda = "ttf"
+)
+
+type SimilarityIB func(name string, distribution SimilarityIBDistribution, lambda SimilarityIBLambda, normalization SimilarityNormalization) SimilarityFunc
+
+func newSimilarityIB() SimilarityIB {
+	return func(name string, distribution SimilarityIBDistribution, lambda SimilarityIBLambda, normalization SimilarityNormalization) SimilarityFunc {
+		return func() map[string]interface{} {
+			r := normalization.properties()
+
+			r["type"] = "IB"
+			r["distribution"] = distribution
+			r["lambda"] = lambda
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// LM DIRICHLET SIMILARITY
+
+type SimilarityLMDirichletProperties func() map[string]interface{}
+type SimilarityLMDirichlet func(name string, properties ...SimilarityLMDirichletProperties) SimilarityFunc
+
+func newSimilarityLMDirichlet() SimilarityLMDirichlet {
+	return func(name string, properties ...SimilarityLMDirichletProperties) SimilarityFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "LMDirichlet"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithMu() sets the mu parameter of the LM Dirichlet similarity.
+func (l SimilarityLMDirichlet) WithMu(value float64) SimilarityLMDirichletProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"mu": value,
+		}
+	}
+}
+
+// LM JELINEK MERCER SIMILARITY
+
+type SimilarityLMJelinekMercerProperties func() map[string]interface{}
+type SimilarityLMJelinekMercer func(name string, properties ...SimilarityLMJelinekMercerProperties) SimilarityFunc
+
+func newSimilarityLMJelinekMercer() SimilarityLMJelinekMercer {
+	return func(name string, properties ...SimilarityLMJelinekMercerProperties) SimilarityFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "LMJelinekMercer"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithLambda() sets the lambda parameter of the LM Jelinek-Mercer similarity.
+func (l SimilarityLMJelinekMercer) WithLambda(value float64) SimilarityLMJelinekMercerProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"lambda": value,
+		}
+	}
+}
+
+// SCRIPTED SIMILARITY
+
+type SimilarityScriptedProperties func() map[string]interface{}
+type SimilarityScripted func(name string, script ScriptConfig, properties ...SimilarityScriptedProperties) SimilarityFunc
+
+func newSimilarityScripted() SimilarityScripted {
+	return func(name string, script ScriptConfig, properties ...SimilarityScriptedProperties) SimilarityFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "scripted"
+			r["script"] = script
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithWeightScript() sets a script computing the document-independent part of the score, which is then evaluated once per term instead of once per document.
+func (s SimilarityScripted) WithWeightScript(value ScriptConfig) SimilarityScriptedProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"weight_script": value,
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package porter
+
+import (
+	"strings"
+)
+
+/*
+
+This file contains the builders for synonym sets managed through the Synonyms API (_synonyms/{set}).
+Unlike inline or file-based synonyms, a synonym set can be changed without reindexing: a synonym filter
+references it with WithSynonymsSet() and WithUpdateable(true), is used only in search analyzers, and
+picks up new rules once the search analyzers of the index are reloaded (see M.UpdateSynonyms()).
+
+*/
+
+type synonyms struct {
+	Equivalent SynonymsEquivalent
+	Explicit   SynonymsExplicit
+}
+
+// NewSynonymsSet() composes synonym rules into a synonym set with the given ID.
+func (a analysis) NewSynonymsSet(id string, rules ...SynonymsRuleFunc) SynonymsSetConfig {
+	r := SynonymsSetConfig{
+		ID:    id,
+		Rules: []SynonymsRuleConfig{},
+	}
+
+	for _, fn := range rules {
+		if fn == nil {
+			continue
+		}
+
+		r.Rules = append(r.Rules, fn())
+	}
+
+	return r
+}
+
+type SynonymsRuleFunc func() SynonymsRuleConfig
+
+// EQUIVALENT SYNONYMS
+
+type SynonymsEquivalent func(id string, terms ...string) SynonymsRuleFunc
+
+// newSynonymsEquivalent() builds a rule whose terms are all treated as equivalent (e.g., "tv, television").
+func newSynonymsEquivalent() SynonymsEquivalent {
+	return func(id string, terms ...string) SynonymsRuleFunc {
+		return func() SynonymsRuleConfig {
+			return SynonymsRuleConfig{
+				ID:       id,
+				Synonyms: strings.Join(terms, ", "),
+			}
+		}
+	}
+}
+
+// EXPLICIT SYNONYMS
+
+type SynonymsExplicit func(id string, from []string, to []string) SynonymsRuleFunc
+
+// newSynonymsExplicit() builds a rule that replaces the terms on the left with the terms on the right (e.g., "laptop => notebook").
+func newSynonymsExplicit() SynonymsExplicit {
+	return func(id string, from []string, to []string) SynonymsRuleFunc {
+		return func() SynonymsRuleConfig {
+			return SynonymsRuleConfig{
+				ID:       id,
+				Synonyms: strings.Join(from, ", ") + " => " + strings.Join(to, ", "),
+			}
+		}
+	}
+}
@@ -0,0 +1,391 @@
+package porter
+
+import (
+	"strings"
+)
+
+/*
+
+This file contains the factory functions and types for constructing configurable tokenizers. Built-in
+tokenizers (AnalyzerCustomTokenizer constants) always run with their defaults; the tokenizers defined
+here carry their own parameters (e.g., WithMinGram(), WithDelimiter()) and are placed into
+AnalysisConfig.Tokenizer.
+
+A custom analyzer references a configured tokenizer by its name, the same way it references a built-in one:
+
+	Analyzer.Custom.WithTokenizer(porter.AnalyzerCustomTokenizer("autocomplete"))
+
+*/
+
+type tokenizer struct {
+	NGram         TokenizerNGram
+	EdgeNGram     TokenizerEdgeNGram
+	Pattern       TokenizerPattern
+	CharGroup     TokenizerCharGroup
+	PathHierarchy TokenizerPathHierarchy
+	Classic       TokenizerClassic
+
+	ICU      TokenizerICU
+	Kuromoji TokenizerKuromoji
+	Nori     TokenizerNori
+}
+
+// NewTokenizer() generates a map of tokenizer names and their corresponding definitions.
+func (a analysis) NewTokenizer(tokenizers ...TokenizerFunc) map[string]interface{} {
+	r := map[string]interface{}{}
+
+	for _, fn := range tokenizers {
+		if fn == nil {
+			continue
+		}
+		for k, v := range fn() {
+			r[k] = v
+		}
+	}
+
+	return r
+}
+
+type TokenizerFunc func() map[string]interface{}
+
+// TokenizerTokenChars defines the character classes kept in the tokens of (edge) n-gram tokenizers.
+type TokenizerTokenChars string
+
+var (
+	TokenizerTokenCharsLetter      TokenizerTokenChars = "letter"
+	TokenizerTokenCharsDigit       TokenizerTokenChars = "digit"
+	TokenizerTokenCharsWhitespace  TokenizerTokenChars = "whitespace"
+	TokenizerTokenCharsPunctuation TokenizerTokenChars = "punctuation"
+	TokenizerTokenCharsSymbol      TokenizerTokenChars = "symbol"
+	TokenizerTokenCharsCustom      TokenizerTokenChars = "custom"
+)
+
+// NGRAM TOKENIZER
+
+type TokenizerNGramProperties func() map[string]interface{}
+type TokenizerNGram func(name string, properties ...TokenizerNGramProperties) TokenizerFunc
+
+func newTokenizerNGram() TokenizerNGram {
+	return func(name string, properties ...TokenizerNGramProperties) TokenizerFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "ngram"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithMinGram() sets the minimum length of characters in a gram.
+func (n TokenizerNGram) WithMinGram(value int) TokenizerNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"min_gram": value,
+		}
+	}
+}
+
+// WithMaxGram() sets the maximum length of characters in a gram.
+func (n TokenizerNGram) WithMaxGram(value int) TokenizerNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"max_gram": value,
+		}
+	}
+}
+
+// WithTokenChars() sets the character classes that should be included in a token.
+func (n TokenizerNGram) WithTokenChars(value []TokenizerTokenChars) TokenizerNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"token_chars": value,
+		}
+	}
+}
+
+// WithCustomTokenChars() sets the characters treated as part of a token when TokenizerTokenCharsCustom is used.
+func (n TokenizerNGram) WithCustomTokenChars(value string) TokenizerNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"custom_token_chars": value,
+		}
+	}
+}
+
+// EDGE NGRAM TOKENIZER
+
+type TokenizerEdgeNGramProperties func() map[string]interface{}
+type TokenizerEdgeNGram func(name string, properties ...TokenizerEdgeNGramProperties) TokenizerFunc
+
+func newTokenizerEdgeNGram() TokenizerEdgeNGram {
+	return func(name string, properties ...TokenizerEdgeNGramProperties) TokenizerFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "edge_ngram"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithMinGram() sets the minimum length of characters in a gram.
+func (e TokenizerEdgeNGram) WithMinGram(value int) TokenizerEdgeNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"min_gram": value,
+		}
+	}
+}
+
+// WithMaxGram() sets the maximum length of characters in a gram.
+func (e TokenizerEdgeNGram) WithMaxGram(value int) TokenizerEdgeNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"max_gram": value,
+		}
+	}
+}
+
+// WithTokenChars() sets the character classes that should be included in a token.
+func (e TokenizerEdgeNGram) WithTokenChars(value []TokenizerTokenChars) TokenizerEdgeNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"token_chars": value,
+		}
+	}
+}
+
+// WithCustomTokenChars() sets the characters treated as part of a token when TokenizerTokenCharsCustom is used.
+func (e TokenizerEdgeNGram) WithCustomTokenChars(value string) TokenizerEdgeNGramProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"custom_token_chars": value,
+		}
+	}
+}
+
+// PATTERN TOKENIZER
+
+type TokenizerPatternProperties func() map[string]interface{}
+type TokenizerPattern func(name string, properties ...TokenizerPatternProperties) TokenizerFunc
+
+func newTokenizerPattern() TokenizerPattern {
+	return func(name string, properties ...TokenizerPatternProperties) TokenizerFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "pattern"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithPattern() sets the regular expression used to split text or, together with WithGroup(), to extract tokens.
+func (p TokenizerPattern) WithPattern(value AnalyzerPatternPattern) TokenizerPatternProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"pattern": value,
+		}
+	}
+}
+
+// WithFlags() configures regex flags (e.g., CASE_INSENSITIVE).
+func (p TokenizerPattern) WithFlags(value []AnalyzerPatternFlags) TokenizerPatternProperties {
+	return func() map[string]interface{} {
+		flags := []string{}
+
+		for _, v := range value {
+			flags = append(flags, string(v))
+		}
+
+		f := strings.Join(flags, "|")
+
+		return map[string]interface{}{
+			"flags": f,
+		}
+	}
+}
+
+// WithGroup() sets which capture group to extract as tokens. The default (-1) splits on the pattern instead.
+func (p TokenizerPattern) WithGroup(value int) TokenizerPatternProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"group": value,
+		}
+	}
+}
+
+// CHAR GROUP TOKENIZER
+
+type TokenizerCharGroupProperties func() map[string]interface{}
+type TokenizerCharGroup func(name string, tokenizeOnChars []string, properties ...TokenizerCharGroupProperties) TokenizerFunc
+
+func newTokenizerCharGroup() TokenizerCharGroup {
+	return func(name string, tokenizeOnChars []string, properties ...TokenizerCharGroupProperties) TokenizerFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "char_group"
+			r["tokenize_on_chars"] = tokenizeOnChars
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithMaxTokenLength() sets the maximum token length; longer tokens are split.
+func (c TokenizerCharGroup) WithMaxTokenLength(value int) TokenizerCharGroupProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"max_token_length": value,
+		}
+	}
+}
+
+// PATH HIERARCHY TOKENIZER
+
+type TokenizerPathHierarchyProperties func() map[string]interface{}
+type TokenizerPathHierarchy func(name string, properties ...TokenizerPathHierarchyProperties) TokenizerFunc
+
+func newTokenizerPathHierarchy() TokenizerPathHierarchy {
+	return func(name string, properties ...TokenizerPathHierarchyProperties) TokenizerFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "path_hierarchy"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithDelimiter() sets the character used as the path separator.
+func (p TokenizerPathHierarchy) WithDelimiter(value string) TokenizerPathHierarchyProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"delimiter": value,
+		}
+	}
+}
+
+// WithReplacement() sets the character used in the tokens instead of the delimiter.
+func (p TokenizerPathHierarchy) WithReplacement(value string) TokenizerPathHierarchyProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"replacement": value,
+		}
+	}
+}
+
+// WithReverse() sets whether the hierarchy is emitted in reverse order (e.g., for domain names).
+func (p TokenizerPathHierarchy) WithReverse(enabled bool) TokenizerPathHierarchyProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"reverse": enabled,
+		}
+	}
+}
+
+// WithSkip() sets the number of initial tokens to skip.
+func (p TokenizerPathHierarchy) WithSkip(value int) TokenizerPathHierarchyProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"skip": value,
+		}
+	}
+}
+
+// CLASSIC TOKENIZER
+
+type TokenizerClassicProperties func() map[string]interface{}
+type TokenizerClassic func(name string, properties ...TokenizerClassicProperties) TokenizerFunc
+
+func newTokenizerClassic() TokenizerClassic {
+	return func(name string, properties ...TokenizerClassicProperties) TokenizerFunc {
+		return func() map[string]interface{} {
+			r := map[string]interface{}{}
+
+			for _, fn := range properties {
+				if fn == nil {
+					continue
+				}
+				for k, v := range fn() {
+					r[k] = v
+				}
+			}
+
+			r["type"] = "classic"
+
+			return map[string]interface{}{
+				name: r,
+			}
+		}
+	}
+}
+
+// WithMaxTokenLength() sets the maximum token length; longer tokens are split.
+func (c TokenizerClassic) WithMaxTokenLength(value int) TokenizerClassicProperties {
+	return func() map[string]interface{} {
+		return map[string]interface{}{
+			"max_token_length": value,
+		}
+	}
+}
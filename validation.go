@@ -0,0 +1,681 @@
+package porter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+/*
+
+This file defines the checks that run on an index definition before it is sent to Elasticsearch.
+
+Elasticsearch answers an invalid mapping with a single 400 error that only describes the first problem
+it finds. validateDefinition() walks the rendered DefinitionConfig{} instead, including object, nested
+and multi-field properties, and reports every problem it finds at once in a ValidationReport{}.
+
+Besides the option checks, the definition is cross-referenced: analyzers, normalizers and similarities
+used by fields, and the tokenizers, filters and character filters used by custom analyzers and
+normalizers, must either be defined in the settings or be built into Elasticsearch or one of its analysis plugins.
+
+*/
+
+var (
+	ErrValidatingDefinition = fmt.Errorf("validation: index definition contains combinations rejected by elasticsearch")
+)
+
+// ValidationProblem{} describes a single problem of an index definition, e.g. a field referencing an undefined analyzer.
+type ValidationProblem struct {
+	// Component is the kind of the offending part of the definition: field, analyzer, normalizer, tokenizer, filter or char_filter.
+	Component string
+	// Name is the full path of the field or the name of the analysis component.
+	Name    string
+	Message string
+}
+
+func (p ValidationProblem) String() string {
+	return fmt.Sprintf("%s [%s]: %s", p.Component, p.Name, p.Message)
+}
+
+// ValidationReport{} collects every problem found in an index definition. It is returned as an error by MigrateIndex() and can be inspected with Validate().
+type ValidationReport struct {
+	Problems []ValidationProblem
+}
+
+func (r ValidationReport) Error() string {
+	problems := make([]string, 0, len(r.Problems))
+	for _, p := range r.Problems {
+		problems = append(problems, p.String())
+	}
+
+	return fmt.Sprintf("%s\n%s", ErrValidatingDefinition, strings.Join(problems, "\n"))
+}
+
+func (r ValidationReport) Unwrap() error {
+	return ErrValidatingDefinition
+}
+
+// Valid() reports whether the definition has no problems.
+func (r ValidationReport) Valid() bool {
+	return len(r.Problems) == 0
+}
+
+func problem(component string, name string, format string, args ...interface{}) ValidationProblem {
+	return ValidationProblem{
+		Component: component,
+		Name:      name,
+		Message:   fmt.Sprintf(format, args...),
+	}
+}
+
+// validateDefinition() checks the index definition and returns a ValidationReport{} with every problem found in it.
+func validateDefinition(d DefinitionConfig) error {
+	r := newValidationReport(d)
+	if r.Valid() {
+		return nil
+	}
+
+	return r
+}
+
+func newValidationReport(d DefinitionConfig) ValidationReport {
+	var problems []ValidationProblem
+
+	refs := references{
+		analysis:     &AnalysisConfig{},
+		similarities: map[string]interface{}{},
+	}
+
+	if d.Settings != nil {
+		if d.Settings.Analysis != nil {
+			refs.analysis = d.Settings.Analysis
+		}
+		if d.Settings.Similarity != nil {
+			refs.similarities = d.Settings.Similarity
+		}
+	}
+
+	refs.searchOnly = searchOnlyAnalyzers(refs.analysis)
+
+	if d.Settings != nil && d.Settings.Analysis != nil {
+		problems = append(problems, validateGrams("tokenizer", d.Settings.MaxNGramDiff, d.Settings.Analysis.Tokenizer)...)
+		problems = append(problems, validateGrams("filter", d.Settings.MaxNGramDiff, d.Settings.Analysis.Filter)...)
+		problems = append(problems, validateCharFilters(d.Settings.Analysis.CharFilter)...)
+		problems = append(problems, validateAnalyzers(refs.analysis)...)
+		problems = append(problems, validateNormalizers(refs.analysis)...)
+	}
+
+	if d.Mappings != nil {
+		fields := map[string]bool{}
+		collectFields("", d.Mappings.Properties, fields)
+
+		problems = append(problems, validateProperties("", d.Mappings.Properties, fields, refs, false)...)
+	}
+
+	return ValidationReport{
+		Problems: problems,
+	}
+}
+
+// references{} holds what fields of the mappings may reference by name.
+type references struct {
+	analysis     *AnalysisConfig
+	similarities map[string]interface{}
+	searchOnly   map[string][]string
+}
+
+// collectFields() gathers the full paths of every field defined in the mappings, including object and nested children.
+func collectFields(path string, properties map[string]interface{}, fields map[string]bool) {
+	for name, v := range properties {
+		field := name
+		if path != "" {
+			field = path + "." + name
+		}
+
+		fields[field] = true
+
+		r, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		children, ok := r["properties"].(map[string]interface{})
+		if ok {
+			collectFields(field, children, fields)
+		}
+	}
+}
+
+func validateProperties(path string, properties map[string]interface{}, fields map[string]bool, refs references, multiField bool) []ValidationProblem {
+	var problems []ValidationProblem
+
+	for _, name := range sortedKeys(properties) {
+		r, ok := properties[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		field := name
+		if path != "" {
+			field = path + "." + name
+		}
+
+		if fmt.Sprint(r["type"]) == "text" {
+			problems = append(problems, validateText(field, r)...)
+		}
+
+		problems = append(problems, validateCopyTo(field, r, fields, multiField)...)
+		problems = append(problems, validateMeta(field, r)...)
+		problems = append(problems, validateReferences(field, r, refs)...)
+
+		children, ok := r["properties"].(map[string]interface{})
+		if ok {
+			problems = append(problems, validateProperties(field, children, fields, refs, false)...)
+		}
+
+		subFields, ok := r["fields"].(map[string]interface{})
+		if ok {
+			problems = append(problems, validateProperties(field, subFields, fields, refs, true)...)
+		}
+	}
+
+	return problems
+}
+
+func validateText(field string, r map[string]interface{}) []ValidationProblem {
+	var problems []ValidationProblem
+
+	indexed := true
+	if index, ok := r["index"].(bool); ok {
+		indexed = index
+	}
+
+	phrases, _ := r["index_phrases"].(bool)
+	prefixes, hasPrefixes := r["index_prefixes"].(map[string]interface{})
+
+	if phrases {
+		switch fmt.Sprint(r["index_options"]) {
+		case string(FieldIndexOptionsDocs), string(FieldIndexOptionsFreqs):
+			problems = append(problems, problem("field", field, "index_phrases requires index_options to include positions"))
+		}
+
+		if !indexed {
+			problems = append(problems, problem("field", field, "index_phrases cannot be set on an unindexed field"))
+		}
+	}
+
+	if hasPrefixes {
+		min, hasMin := numberOf(prefixes["min_chars"])
+		max, hasMax := numberOf(prefixes["max_chars"])
+
+		if hasMin && min < 1 {
+			problems = append(problems, problem("field", field, "index_prefixes min_chars [%v] must be greater than zero", min))
+		}
+		if hasMax && max >= 20 {
+			problems = append(problems, problem("field", field, "index_prefixes max_chars [%v] must be less than 20", max))
+		}
+		if hasMin && hasMax && min > max {
+			problems = append(problems, problem("field", field, "index_prefixes min_chars [%v] must be less than max_chars [%v]", min, max))
+		}
+
+		if !indexed {
+			problems = append(problems, problem("field", field, "index_prefixes cannot be set on an unindexed field"))
+		}
+	}
+
+	_, hasAnalyzer := r["analyzer"]
+	_, hasSearchAnalyzer := r["search_analyzer"]
+	_, hasSearchQuoteAnalyzer := r["search_quote_analyzer"]
+
+	if hasSearchAnalyzer && !hasAnalyzer {
+		problems = append(problems, problem("field", field, "analyzer must be set when search_analyzer is set"))
+	}
+	if hasSearchQuoteAnalyzer && !hasSearchAnalyzer {
+		problems = append(problems, problem("field", field, "search_analyzer must be set when search_quote_analyzer is set"))
+	}
+
+	return problems
+}
+
+func validateCopyTo(field string, r map[string]interface{}, fields map[string]bool, multiField bool) []ValidationProblem {
+	var problems []ValidationProblem
+
+	var targets []string
+
+	switch copyTo := r["copy_to"].(type) {
+	case string:
+		targets = []string{copyTo}
+	case []string:
+		targets = copyTo
+	case []interface{}:
+		for _, t := range copyTo {
+			targets = append(targets, fmt.Sprint(t))
+		}
+	}
+
+	if len(targets) > 0 && multiField {
+		problems = append(problems, problem("field", field, "copy_to cannot be set on a multi-field"))
+	}
+
+	for _, t := range targets {
+		if t == field {
+			problems = append(problems, problem("field", field, "copy_to cannot target the field itself"))
+
+			continue
+		}
+		if !fields[t] {
+			problems = append(problems, problem("field", field, "copy_to target [%s] is not defined in the mappings", t))
+		}
+	}
+
+	return problems
+}
+
+func validateMeta(field string, r map[string]interface{}) []ValidationProblem {
+	var problems []ValidationProblem
+
+	var meta map[string]string
+
+	switch m := r["meta"].(type) {
+	case map[string]string:
+		meta = m
+	case map[string]interface{}:
+		meta = map[string]string{}
+		for k, v := range m {
+			meta[k] = fmt.Sprint(v)
+		}
+	default:
+		return nil
+	}
+
+	if len(meta) > 5 {
+		problems = append(problems, problem("field", field, "meta can have at most 5 entries, got [%d]", len(meta)))
+	}
+
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if len(k) > 20 {
+			problems = append(problems, problem("field", field, "meta key [%s] must be at most 20 characters", k))
+		}
+		if len(meta[k]) > 50 {
+			problems = append(problems, problem("field", field, "meta value of [%s] must be at most 50 characters", k))
+		}
+	}
+
+	return problems
+}
+
+// validateGrams() checks the gram sizes of (edge) n-gram tokenizers or token filters.
+func validateGrams(kind string, maxNGramDiff int, components map[string]interface{}) []ValidationProblem {
+	var problems []ValidationProblem
+
+	// Elasticsearch defaults index.max_ngram_diff to 1.
+	if maxNGramDiff == 0 {
+		maxNGramDiff = 1
+	}
+
+	for _, name := range sortedKeys(components) {
+		r, ok := components[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		t := fmt.Sprint(r["type"])
+		if t != "ngram" && t != "edge_ngram" {
+			continue
+		}
+
+		min, hasMin := numberOf(r["min_gram"])
+		if !hasMin {
+			min = 1
+		}
+		max, hasMax := numberOf(r["max_gram"])
+		if !hasMax {
+			max = 2
+		}
+
+		if min > max {
+			problems = append(problems, problem(kind, name, "min_gram [%v] must not be greater than max_gram [%v]", min, max))
+		}
+		if t == "ngram" && max-min > float64(maxNGramDiff) {
+			problems = append(problems, problem(kind, name, "the difference between max_gram and min_gram [%v] must be less than or equal to max_ngram_diff [%d]", max-min, maxNGramDiff))
+		}
+	}
+
+	return problems
+}
+
+func validateCharFilters(charFilters map[string]interface{}) []ValidationProblem {
+	var problems []ValidationProblem
+
+	for _, name := range sortedKeys(charFilters) {
+		r, ok := charFilters[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		_, hasMappings := r["mappings"]
+		_, hasMappingsPath := r["mappings_path"]
+
+		if fmt.Sprint(r["type"]) == "mapping" && !hasMappings && !hasMappingsPath {
+			problems = append(problems, problem("char_filter", name, "mapping requires either mappings or mappings_path"))
+		}
+	}
+
+	return problems
+}
+
+// searchOnlyAnalyzers() finds the analyzers that use updateable filters, mapped to those filters.
+func searchOnlyAnalyzers(a *AnalysisConfig) map[string][]string {
+	updateable := map[string]bool{}
+
+	for name, v := range a.Filter {
+		r, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		enabled, _ := r["updateable"].(bool)
+		if enabled {
+			updateable[name] = true
+		}
+	}
+
+	analyzers := map[string][]string{}
+
+	for name, v := range a.Analyzer {
+		r, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, f := range stringsOf(r["filter"]) {
+			if updateable[f] {
+				analyzers[name] = append(analyzers[name], f)
+			}
+		}
+	}
+
+	return analyzers
+}
+
+// validateReferences() checks that the analyzers, normalizer and similarity of a field are defined in the settings or built in.
+func validateReferences(field string, r map[string]interface{}, refs references) []ValidationProblem {
+	var problems []ValidationProblem
+
+	for _, key := range []string{"analyzer", "search_analyzer", "search_quote_analyzer"} {
+		v, ok := r[key]
+		if !ok {
+			continue
+		}
+
+		analyzer := fmt.Sprint(v)
+
+		_, defined := refs.analysis.Analyzer[analyzer]
+		if !defined && !builtinAnalyzers[analyzer] && pluginAnalyzers[analyzer] == "" {
+			problems = append(problems, problem("field", field, "%s [%s] is not defined in the analysis settings", key, analyzer))
+		}
+
+		filters, ok := refs.searchOnly[analyzer]
+		if ok && key == "analyzer" {
+			problems = append(problems, problem("field", field, "analyzer [%s] contains updateable filters [%s] and can only be used as a search_analyzer", analyzer, strings.Join(filters, ", ")))
+		}
+	}
+
+	if v, ok := r["normalizer"]; ok {
+		normalizer := fmt.Sprint(v)
+
+		_, defined := refs.analysis.Normalizer[normalizer]
+		if !defined && !builtinNormalizers[normalizer] {
+			problems = append(problems, problem("field", field, "normalizer [%s] is not defined in the analysis settings", normalizer))
+		}
+	}
+
+	if v, ok := r["similarity"]; ok {
+		similarity := fmt.Sprint(v)
+
+		_, defined := refs.similarities[similarity]
+		if !defined && !builtinSimilarities[similarity] {
+			problems = append(problems, problem("field", field, "similarity [%s] is not defined in the index settings", similarity))
+		}
+	}
+
+	return problems
+}
+
+// validateAnalyzers() checks that custom analyzers only reference tokenizers, filters and character filters that exist. Filters that require parameters must be configured in the analysis settings.
+func validateAnalyzers(a *AnalysisConfig) []ValidationProblem {
+	var problems []ValidationProblem
+
+	for _, name := range sortedKeys(a.Analyzer) {
+		r, ok := a.Analyzer[name].(map[string]interface{})
+		if !ok || fmt.Sprint(r["type"]) != "custom" {
+			continue
+		}
+
+		tokenizer, ok := r["tokenizer"]
+		if !ok {
+			problems = append(problems, problem("analyzer", name, "custom analyzer must set a tokenizer"))
+		} else {
+			t := fmt.Sprint(tokenizer)
+
+			_, defined := a.Tokenizer[t]
+			if !defined && !builtinTokenizers[t] && pluginTokenizers[t] == "" {
+				problems = append(problems, problem("analyzer", name, "tokenizer [%s] is not defined in the analysis settings", t))
+			}
+		}
+
+		for _, f := range stringsOf(r["filter"]) {
+			_, defined := a.Filter[f]
+			if defined {
+				continue
+			}
+
+			switch {
+			case parameterizedFilters[f]:
+				problems = append(problems, problem("analyzer", name, "filter [%s] requires parameters and must be defined in the analysis settings", f))
+			case !builtinFilters[f] && pluginFilters[f] == "":
+				problems = append(problems, problem("analyzer", name, "filter [%s] is not defined in the analysis settings", f))
+			}
+		}
+
+		problems = append(problems, validateCharFilterReferences("analyzer", name, r, a)...)
+	}
+
+	return problems
+}
+
+// validateNormalizers() checks that custom normalizers only reference existing, normalizer-safe filters and character filters.
+func validateNormalizers(a *AnalysisConfig) []ValidationProblem {
+	var problems []ValidationProblem
+
+	for _, name := range sortedKeys(a.Normalizer) {
+		r, ok := a.Normalizer[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, f := range stringsOf(r["filter"]) {
+			v, defined := a.Filter[f]
+			if !defined {
+				switch {
+				case parameterizedFilters[f]:
+					problems = append(problems, problem("normalizer", name, "filter [%s] requires parameters and must be defined in the analysis settings", f))
+				case normalizerFilters[f]:
+				case builtinFilters[f] || pluginFilters[f] != "":
+					problems = append(problems, problem("normalizer", name, "filter [%s] cannot be used in a normalizer", f))
+				default:
+					problems = append(problems, problem("normalizer", name, "filter [%s] is not defined in the analysis settings", f))
+				}
+
+				continue
+			}
+
+			t := ""
+			if filter, ok := v.(map[string]interface{}); ok {
+				t = fmt.Sprint(filter["type"])
+			}
+
+			if !normalizerFilters[t] {
+				problems = append(problems, problem("normalizer", name, "filter [%s] of type [%s] cannot be used in a normalizer", f, t))
+			}
+		}
+
+		problems = append(problems, validateCharFilterReferences("normalizer", name, r, a)...)
+
+		for _, c := range stringsOf(r["char_filter"]) {
+			v, defined := a.CharFilter[c]
+			if !defined {
+				if c == string(AnalyzerCustomCharFilterHTMLStrip) || pluginCharFilters[c] != "" {
+					problems = append(problems, problem("normalizer", name, "char_filter [%s] cannot be used in a normalizer", c))
+				}
+
+				continue
+			}
+
+			t := ""
+			if charFilter, ok := v.(map[string]interface{}); ok {
+				t = fmt.Sprint(charFilter["type"])
+			}
+
+			if !normalizerCharFilters[t] {
+				problems = append(problems, problem("normalizer", name, "char_filter [%s] of type [%s] cannot be used in a normalizer", c, t))
+			}
+		}
+	}
+
+	return problems
+}
+
+// validateCharFilterReferences() checks the character filters of an analyzer or normalizer. The mapping and pattern_replace character filters require parameters, so they can only be used once configured.
+func validateCharFilterReferences(component string, name string, r map[string]interface{}, a *AnalysisConfig) []ValidationProblem {
+	var problems []ValidationProblem
+
+	for _, c := range stringsOf(r["char_filter"]) {
+		_, defined := a.CharFilter[c]
+		if defined || c == string(AnalyzerCustomCharFilterHTMLStrip) || pluginCharFilters[c] != "" {
+			continue
+		}
+
+		if c == string(AnalyzerCustomCharFilterMapping) || c == string(AnalyzerCustomCharFilterPatternReplace) {
+			problems = append(problems, problem(component, name, "char_filter [%s] requires parameters and must be defined in the analysis settings", c))
+
+			continue
+		}
+
+		problems = append(problems, problem(component, name, "char_filter [%s] is not defined in the analysis settings", c))
+	}
+
+	return problems
+}
+
+// The names below are built into Elasticsearch and can be referenced without being defined in the analysis settings.
+var (
+	builtinAnalyzers = map[string]bool{
+		"standard": true, "simple": true, "whitespace": true, "stop": true, "keyword": true, "pattern": true, "fingerprint": true,
+
+		"arabic": true, "armenian": true, "basque": true, "bengali": true, "brazilian": true, "bulgarian": true, "catalan": true,
+		"cjk": true, "czech": true, "danish": true, "dutch": true, "english": true, "estonian": true, "finnish": true,
+		"french": true, "galician": true, "german": true, "greek": true, "hindi": true, "hungarian": true, "indonesian": true,
+		"irish": true, "italian": true, "latvian": true, "lithuanian": true, "norwegian": true, "persian": true, "portuguese": true,
+		"romanian": true, "russian": true, "serbian": true, "sorani": true, "spanish": true, "swedish": true, "turkish": true,
+		"thai": true,
+	}
+
+	builtinNormalizers = map[string]bool{
+		NormalizerBuiltinLowercase: true,
+	}
+
+	builtinSimilarities = map[string]bool{
+		"BM25": true, "boolean": true,
+	}
+
+	builtinTokenizers = map[string]bool{
+		"standard": true, "letter": true, "lowercase": true, "whitespace": true, "uax_url_email": true, "classic": true,
+		"thai": true, "ngram": true, "edge_ngram": true, "keyword": true, "pattern": true, "simple_pattern": true,
+		"char_group": true, "simple_pattern_split": true, "path_hierarchy": true,
+	}
+
+	builtinFilters = map[string]bool{
+		"apostrophe": true, "asciifolding": true, "cjk_bigram": true, "cjk_width": true, "classic": true, "common_grams": true,
+		"condition": true, "decimal_digit": true, "delimited_payload": true, "dictionary_decompounder": true, "edge_ngram": true,
+		"elision": true, "fingerprint": true, "flatten_graph": true, "hunspell": true, "hyphenation_decompounder": true,
+		"keep_types": true, "keep": true, "keyword_marker": true, "keyword_repeat": true, "kstem": true, "length": true,
+		"limit": true, "lowercase": true, "min_hash": true, "multiplexer": true, "ngram": true, "pattern_capture": true,
+		"pattern_replace": true, "porter_stem": true, "predicate_token_filter": true, "remove_duplicates": true,
+		"reverse": true, "shingle": true, "snowball": true, "stemmer": true, "stemmer_override": true, "stop": true,
+		"synonym": true, "synonym_graph": true, "trim": true, "truncate": true, "unique": true, "uppercase": true,
+		"word_delimiter": true, "word_delimiter_graph": true,
+
+		"arabic_normalization": true, "bengali_normalization": true, "german_normalization": true, "hindi_normalization": true,
+		"indic_normalization": true, "persian_normalization": true, "scandinavian_folding": true, "scandinavian_normalization": true,
+		"serbian_normalization": true, "sorani_normalization": true, "arabic_stem": true, "brazilian_stem": true,
+		"czech_stem": true, "dutch_stem": true, "french_stem": true, "german_stem": true, "russian_stem": true,
+	}
+
+	// parameterizedFilters are the built-in filters Elasticsearch rejects without parameters, so they can only be used once configured.
+	parameterizedFilters = map[string]bool{
+		"common_grams": true, "condition": true, "dictionary_decompounder": true, "hunspell": true,
+		"hyphenation_decompounder": true, "keep": true, "keep_types": true, "keyword_marker": true, "pattern_capture": true,
+		"pattern_replace": true, "predicate_token_filter": true, "stemmer_override": true, "synonym": true, "synonym_graph": true,
+	}
+
+	// normalizerFilters are the filter types that work on a single character stream and so can be used in a normalizer.
+	normalizerFilters = map[string]bool{
+		"arabic_normalization": true, "asciifolding": true, "bengali_normalization": true, "cjk_width": true,
+		"decimal_digit": true, "elision": true, "german_normalization": true, "hindi_normalization": true,
+		"indic_normalization": true, "lowercase": true, "pattern_replace": true, "persian_normalization": true,
+		"scandinavian_folding": true, "serbian_normalization": true, "sorani_normalization": true, "trim": true,
+		"uppercase": true, "icu_folding": true, "icu_normalizer": true,
+	}
+
+	// normalizerCharFilters are the character filter types that can be used in a normalizer.
+	normalizerCharFilters = map[string]bool{
+		"mapping": true, "pattern_replace": true,
+	}
+)
+
+// stringsOf() reads a list of names set either by a builder (e.g., []AnalyzerCustomFilter) or decoded from JSON.
+func stringsOf(v interface{}) []string {
+	var r []string
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return r
+	}
+
+	for c := 0; c < rv.Len(); c++ {
+		r = append(r, fmt.Sprint(rv.Index(c).Interface()))
+	}
+
+	return r
+}
+
+// sortedKeys() returns the keys of a map in a stable order, so problems are always reported in the same order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// numberOf() reads a number set either by a field builder or decoded from JSON.
+func numberOf(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}